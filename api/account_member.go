@@ -0,0 +1,149 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/haniifac/simplebank/db/sqlc"
+	"github.com/haniifac/simplebank/token"
+	"github.com/lib/pq"
+)
+
+type AccountMembersUriParams struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type AccountMemberUriParams struct {
+	ID       int64  `uri:"id" binding:"required,min=1"`
+	Username string `uri:"username" binding:"required"`
+}
+
+// InviteAccountMemberParams adds a user to an account. Co-owners can move money like the owner,
+// viewers can only see the account.
+type InviteAccountMemberParams struct {
+	Username string `json:"username" binding:"required"`
+	Role     string `json:"role" binding:"required,oneof=co_owner viewer"`
+}
+
+// listAccountMembers returns the members of an account to any of its members
+func (server *Server) listAccountMembers(ctx *gin.Context) {
+	var uri AccountMembersUriParams
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	if _, valid := server.ownedAccount(ctx, uri.ID); !valid {
+		return
+	}
+
+	members, err := server.store.ListAccountMembers(ctx, uri.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, members)
+}
+
+// inviteAccountMember lets the owner of an account share it with another user
+func (server *Server) inviteAccountMember(ctx *gin.Context) {
+	var uri AccountMembersUriParams
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	var req InviteAccountMemberParams
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	if _, valid := server.ownedAccount(ctx, uri.ID, db.MemberOwner); !valid {
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	member, err := server.store.CreateAccountMember(ctx, db.CreateAccountMemberParams{
+		AccountID: uri.ID,
+		Username:  req.Username,
+		Role:      req.Role,
+		InvitedBy: sql.NullString{String: authPayload.Username, Valid: true},
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case "unique_violation":
+				err := fmt.Errorf("user %s is already a member of account %d", req.Username, uri.ID)
+				ctx.JSON(http.StatusConflict, errResponse(err))
+				return
+			case "foreign_key_violation":
+				err := fmt.Errorf("user %s not found", req.Username)
+				ctx.JSON(http.StatusNotFound, errResponse(err))
+				return
+			}
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, member)
+}
+
+// removeAccountMember lets the owner of an account remove a member, or a member leave the account.
+// The owner can never be removed.
+func (server *Server) removeAccountMember(ctx *gin.Context) {
+	var uri AccountMemberUriParams
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	roles := []string{db.MemberOwner}
+	if uri.Username == authPayload.Username {
+		roles = nil
+	}
+	if _, valid := server.ownedAccount(ctx, uri.ID, roles...); !valid {
+		return
+	}
+
+	member, err := server.store.GetAccountMember(ctx, db.GetAccountMemberParams{
+		AccountID: uri.ID,
+		Username:  uri.Username,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err := fmt.Errorf("user %s is not a member of account %d", uri.Username, uri.ID)
+			ctx.JSON(http.StatusNotFound, errResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	if member.Role == db.MemberOwner {
+		err := fmt.Errorf("the owner of account %d cannot be removed", uri.ID)
+		ctx.JSON(http.StatusForbidden, errResponse(err))
+		return
+	}
+
+	err = server.store.DeleteAccountMember(ctx, db.DeleteAccountMemberParams{
+		AccountID: uri.ID,
+		Username:  uri.Username,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, member)
+}
@@ -0,0 +1,153 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/haniifac/simplebank/db/sqlc"
+	"github.com/haniifac/simplebank/token"
+)
+
+type AccountStatusUriParams struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// freezeAccount stops an active account from sending or receiving money
+func (server *Server) freezeAccount(ctx *gin.Context) {
+	server.changeAccountStatus(ctx, db.AccountActive, db.AccountFrozen, func(accountID int64) bool {
+		_, valid := server.managedAccount(ctx, accountID, spendingRoles...)
+		return valid
+	})
+}
+
+// unfreezeAccount reactivates a frozen account. Only admins can, as a member may have frozen it because the
+// credentials of another member were compromised.
+func (server *Server) unfreezeAccount(ctx *gin.Context) {
+	server.changeAccountStatus(ctx, db.AccountFrozen, db.AccountActive, func(int64) bool {
+		return server.requireAdmin(ctx, "unfreeze accounts")
+	})
+}
+
+// changeAccountStatus moves the account from one status to the other once allowed has accepted the user,
+// allowed writes the error response when it does not
+func (server *Server) changeAccountStatus(ctx *gin.Context, from, to string, allowed func(accountID int64) bool) {
+	var uri AccountStatusUriParams
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	if !allowed(uri.ID) {
+		return
+	}
+
+	account, err := server.store.UpdateAccountStatus(ctx, db.UpdateAccountStatusParams{
+		Status:        to,
+		ID:            uri.ID,
+		CurrentStatus: from,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err := fmt.Errorf("account %d is not %s", uri.ID, from)
+			ctx.JSON(http.StatusConflict, errResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, account)
+}
+
+// closeAccount closes an account for good, which is only possible once its balance is zero and no hold,
+// scheduled transfer or standing order still moves money in or out of it
+func (server *Server) closeAccount(ctx *gin.Context) {
+	var uri AccountStatusUriParams
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	current, valid := server.managedAccount(ctx, uri.ID, db.MemberOwner)
+	if !valid {
+		return
+	}
+
+	account, err := server.store.CloseAccount(ctx, uri.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			if current.Status == db.AccountClosed {
+				err := fmt.Errorf("account %d is already closed", uri.ID)
+				ctx.JSON(http.StatusConflict, errResponse(err))
+				return
+			}
+
+			commitments, err := server.store.GetAccountCommitments(ctx, uri.ID)
+			if err != nil {
+				ctx.JSON(http.StatusInternalServerError, errResponse(err))
+				return
+			}
+
+			switch {
+			case commitments.ActiveHolds > 0:
+				err = fmt.Errorf("account %d has %d active holds", uri.ID, commitments.ActiveHolds)
+			case commitments.PendingScheduledTransfers > 0:
+				err = fmt.Errorf("account %d has %d pending scheduled transfers", uri.ID, commitments.PendingScheduledTransfers)
+			case commitments.ActiveStandingOrders > 0:
+				err = fmt.Errorf("account %d has %d active standing orders", uri.ID, commitments.ActiveStandingOrders)
+			default:
+				err = fmt.Errorf("account %d can only be closed with a zero balance", uri.ID)
+			}
+			ctx.JSON(http.StatusUnprocessableEntity, errResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, account)
+}
+
+// managedAccount loads an account the authenticated user is a member of with one of the given roles, any role
+// when none are given, or any account for admins. The error response is written when the account cannot be managed.
+func (server *Server) managedAccount(ctx *gin.Context, accountID int64, roles ...string) (db.Account, bool) {
+	account, err := server.store.GetAccount(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err := fmt.Errorf("account %d not found", accountID)
+			ctx.JSON(http.StatusNotFound, errResponse(err))
+			return account, false
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return account, false
+	}
+
+	status, err := server.checkAccountRole(ctx, accountID, roles...)
+	if err == nil {
+		return account, true
+	}
+	if status != http.StatusForbidden {
+		ctx.JSON(status, errResponse(err))
+		return account, false
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	isAdmin, adminErr := server.isAdmin(ctx, authPayload.Username)
+	if adminErr != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(adminErr))
+		return account, false
+	}
+
+	if !isAdmin {
+		ctx.JSON(http.StatusForbidden, errResponse(err))
+		return account, false
+	}
+
+	return account, true
+}
@@ -0,0 +1,219 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/haniifac/simplebank/db/mock"
+	db "github.com/haniifac/simplebank/db/sqlc"
+	"github.com/haniifac/simplebank/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAccountStatusAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	admin, _ := randomUser(t)
+	admin.Role = util.AdminRole
+	other, _ := randomUser(t)
+	other.Role = util.DepositorRole
+
+	account := randomAccount(user.Username)
+
+	frozen := account
+	frozen.Status = db.AccountFrozen
+
+	empty := account
+	empty.Balance = 0
+	closed := empty
+	closed.Status = db.AccountClosed
+
+	testCases := []struct {
+		name          string
+		action        string
+		username      string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "Freeze",
+			action:   "freeze",
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				expectMember(store, account.ID, user.Username, db.MemberOwner)
+				store.EXPECT().
+					UpdateAccountStatus(gomock.Any(), gomock.Eq(db.UpdateAccountStatusParams{
+						Status:        db.AccountFrozen,
+						ID:            account.ID,
+						CurrentStatus: db.AccountActive,
+					})).
+					Times(1).
+					Return(frozen, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.Account
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, db.AccountFrozen, got.Status)
+			},
+		},
+		{
+			name:     "FreezeNotActive",
+			action:   "freeze",
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(frozen, nil)
+				expectMember(store, account.ID, user.Username, db.MemberOwner)
+				store.EXPECT().UpdateAccountStatus(gomock.Any(), gomock.Any()).Times(1).Return(db.Account{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:     "UnfreezeByAdmin",
+			action:   "unfreeze",
+			username: admin.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(admin.Username)).Times(1).Return(admin, nil)
+				store.EXPECT().
+					UpdateAccountStatus(gomock.Any(), gomock.Eq(db.UpdateAccountStatusParams{
+						Status:        db.AccountActive,
+						ID:            account.ID,
+						CurrentStatus: db.AccountFrozen,
+					})).
+					Times(1).
+					Return(account, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:     "UnfreezeByOwner",
+			action:   "unfreeze",
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().UpdateAccountStatus(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:     "FreezeByOtherUser",
+			action:   "freeze",
+			username: other.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				expectNotMember(store, account.ID, other.Username)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(other.Username)).Times(1).Return(other, nil)
+				store.EXPECT().UpdateAccountStatus(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:     "CloseByCoOwner",
+			action:   "close",
+			username: other.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(empty, nil)
+				expectMember(store, account.ID, other.Username, db.MemberCoOwner)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(other.Username)).Times(1).Return(other, nil)
+				store.EXPECT().CloseAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:     "Close",
+			action:   "close",
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(empty, nil)
+				expectMember(store, account.ID, user.Username, db.MemberOwner)
+				store.EXPECT().CloseAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(closed, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:     "CloseWithBalance",
+			action:   "close",
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				expectMember(store, account.ID, user.Username, db.MemberOwner)
+				store.EXPECT().CloseAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(db.Account{}, sql.ErrNoRows)
+				store.EXPECT().GetAccountCommitments(gomock.Any(), gomock.Eq(account.ID)).Times(1)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "zero balance")
+			},
+		},
+		{
+			name:     "CloseWithStandingOrder",
+			action:   "close",
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(empty, nil)
+				expectMember(store, account.ID, user.Username, db.MemberOwner)
+				store.EXPECT().CloseAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(db.Account{}, sql.ErrNoRows)
+				store.EXPECT().
+					GetAccountCommitments(gomock.Any(), gomock.Eq(account.ID)).
+					Times(1).
+					Return(db.GetAccountCommitmentsRow{ActiveStandingOrders: 1}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "standing orders")
+			},
+		},
+		{
+			name:     "CloseAlreadyClosed",
+			action:   "close",
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(closed, nil)
+				expectMember(store, account.ID, user.Username, db.MemberOwner)
+				store.EXPECT().CloseAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(db.Account{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/accounts/%d/%s", account.ID, tc.action)
+			req := httptest.NewRequest(http.MethodPost, url, nil)
+			addAuthorization(t, req, server.tokenMaker, tc.username, "bearer", time.Minute)
+			server.router.ServeHTTP(recorder, req)
+
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -3,23 +3,42 @@ package api
 import (
 	"database/sql"
 	"errors"
+	"fmt"
 	"net/http"
+	"slices"
 
 	"github.com/gin-gonic/gin"
 	db "github.com/haniifac/simplebank/db/sqlc"
 	"github.com/haniifac/simplebank/token"
+	"github.com/haniifac/simplebank/util"
 	"github.com/lib/pq"
 )
 
+// accountNumberConstraint is violated when a generated account number is already taken
+const accountNumberConstraint = "accounts_number_key"
+
+// accountNumberAttempts is how many account numbers are drawn before creating an account fails
+const accountNumberAttempts = 3
+
 type CreateAccountParams struct {
 	Owner    string `json:"owner" binding:"required"`
 	Currency string `json:"currency" binding:"required,currency"`
+	// Kind defaults to a checking account
+	Kind string `json:"kind" binding:"omitempty,oneof=checking savings"`
 }
 
 type GetAccountParams struct {
 	ID int64 `uri:"id" binding:"required,min=1"`
 }
 
+// AccountResponse adds the balance available for transfers, which includes the overdraft limit
+// and excludes funds reserved by active holds
+type AccountResponse struct {
+	db.Account
+	AvailableBalance int64 `json:"available_balance"`
+	OverdraftInUse   int64 `json:"overdraft_in_use"`
+}
+
 type ListAccountsParams struct {
 	PageID   int32 `form:"page_id" binding:"required,min=1"`
 	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
@@ -34,13 +53,19 @@ func (server *Server) createAccount(ctx *gin.Context) {
 
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
 
+	kind := req.Kind
+	if kind == "" {
+		kind = db.AccountChecking
+	}
+
 	arg := db.CreateAccountParams{
 		Owner:    authPayload.Username,
 		Currency: req.Currency,
 		Balance:  0,
+		Kind:     kind,
 	}
 
-	account, err := server.store.CreateAccount(ctx, arg)
+	account, err := server.createAccountWithNumber(ctx, arg)
 	if err != nil {
 		if errName, ok := err.(*pq.Error); ok {
 			switch errName.Code.Name() {
@@ -56,6 +81,24 @@ func (server *Server) createAccount(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, account)
 }
 
+// createAccountWithNumber creates the account with a new random account number,
+// drawing another one in the unlikely case that the number is taken
+func (server *Server) createAccountWithNumber(ctx *gin.Context, arg db.CreateAccountParams) (db.Account, error) {
+	for attempt := 1; ; attempt++ {
+		number, err := util.NewAccountNumber()
+		if err != nil {
+			return db.Account{}, err
+		}
+		arg.Number = number
+
+		account, err := server.store.CreateAccountTx(ctx, arg)
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Constraint == accountNumberConstraint && attempt < accountNumberAttempts {
+			continue
+		}
+		return account, err
+	}
+}
+
 func (server *Server) getAccount(ctx *gin.Context) {
 	var req GetAccountParams
 	if err := ctx.ShouldBindUri(&req); err != nil {
@@ -63,26 +106,22 @@ func (server *Server) getAccount(ctx *gin.Context) {
 		return
 	}
 
-	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
-
-	account, err := server.store.GetAccount(ctx, req.ID)
-	if err != nil {
-		switch {
-		case errors.Is(err, sql.ErrNoRows):
-			ctx.JSON(http.StatusNotFound, errResponse(err))
-		default:
-			ctx.JSON(http.StatusInternalServerError, errResponse(err))
-		}
+	account, valid := server.ownedAccount(ctx, req.ID)
+	if !valid {
 		return
 	}
 
-	if account.Owner != authPayload.Username {
-		err := errors.New("account does not belong to the authenticated user")
-		ctx.JSON(http.StatusForbidden, errResponse(err))
+	held, err := server.store.GetHeldAmount(ctx, account.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
 		return
 	}
 
-	ctx.JSON(http.StatusOK, account)
+	ctx.JSON(http.StatusOK, AccountResponse{
+		Account:          account,
+		AvailableBalance: db.AvailableBalance(account, held),
+		OverdraftInUse:   db.OverdraftInUse(account.Balance),
+	})
 }
 
 func (server *Server) listAccounts(ctx *gin.Context) {
@@ -94,10 +133,10 @@ func (server *Server) listAccounts(ctx *gin.Context) {
 
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
 
-	accounts, err := server.store.ListAccounts(ctx, db.ListAccountsParams{
-		Owner:  authPayload.Username,
-		Limit:  req.PageSize,
-		Offset: req.PageSize * (req.PageID - 1),
+	accounts, err := server.store.ListMemberAccounts(ctx, db.ListMemberAccountsParams{
+		Username: authPayload.Username,
+		Limit:    req.PageSize,
+		Offset:   req.PageSize * (req.PageID - 1),
 	})
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errResponse(err))
@@ -107,3 +146,61 @@ func (server *Server) listAccounts(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, accounts)
 
 }
+
+// spendingRoles are the member roles that may move money out of an account
+var spendingRoles = db.SpendingRoles
+
+// ownedAccount loads an account the authenticated user is a member of with one of the given roles, any role
+// when none are given, and writes the error response when the account cannot be used
+func (server *Server) ownedAccount(ctx *gin.Context, accountID int64, roles ...string) (db.Account, bool) {
+	account, err := server.store.GetAccount(ctx, accountID)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			ctx.JSON(http.StatusNotFound, errResponse(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		}
+		return account, false
+	}
+
+	if !server.requireAccountRole(ctx, accountID, roles...) {
+		return account, false
+	}
+
+	return account, true
+}
+
+// requireAccountRole checks the authenticated user is a member of the account with one of the given roles,
+// any role when none are given, and writes the error response when they are not
+func (server *Server) requireAccountRole(ctx *gin.Context, accountID int64, roles ...string) bool {
+	if status, err := server.checkAccountRole(ctx, accountID, roles...); err != nil {
+		ctx.JSON(status, errResponse(err))
+		return false
+	}
+
+	return true
+}
+
+// checkAccountRole is requireAccountRole without writing the response, it returns the status to respond with instead
+func (server *Server) checkAccountRole(ctx *gin.Context, accountID int64, roles ...string) (int, error) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	member, err := server.store.GetAccountMember(ctx, db.GetAccountMemberParams{
+		AccountID: accountID,
+		Username:  authPayload.Username,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return http.StatusForbidden, fmt.Errorf("account %d does not belong to the authenticated user %s", accountID, authPayload.Username)
+		}
+
+		return http.StatusInternalServerError, err
+	}
+
+	if len(roles) > 0 && !slices.Contains(roles, member.Role) {
+		return http.StatusForbidden, fmt.Errorf("%s of account %d is not allowed to do this", member.Role, accountID)
+	}
+
+	return http.StatusOK, nil
+}
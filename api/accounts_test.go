@@ -1,27 +1,36 @@
 package api
 
 import (
+	"bytes"
 	"database/sql"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"testing"
 	"time"
 
+	"github.com/gin-gonic/gin"
 	mockdb "github.com/haniifac/simplebank/db/mock"
 	db "github.com/haniifac/simplebank/db/sqlc"
 	"github.com/haniifac/simplebank/token"
 	"github.com/haniifac/simplebank/util"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
 
 func TestGetAccountAPI(t *testing.T) {
 	user, _ := randomUser(t)
+	viewer, _ := randomUser(t)
 	account1 := randomAccount(user.Username)
 
+	overdrawn := randomAccount(user.Username)
+	overdrawn.Balance = -30
+	overdrawn.OverdraftLimit = 100
+
 	testCases := []struct {
 		name          string
 		accountID     int64
@@ -39,13 +48,96 @@ func TestGetAccountAPI(t *testing.T) {
 					Times(1).
 					Return(account1, nil)
 
+				expectMember(store, account1.ID, user.Username, db.MemberOwner)
+
+				store.EXPECT().
+					GetHeldAmount(gomock.Any(), gomock.Eq(account1.ID)).
+					Times(1).
+					Return(int64(10), nil)
 			},
 			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, req, tokenMaker, user.Username, "bearer", time.Minute)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
-				requireBodyMatcherAccount(t, recorder, account1)
+
+				var got AccountResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, account1, got.Account)
+				require.Equal(t, account1.Balance-10, got.AvailableBalance)
+			},
+		},
+		{
+			name:      "Overdrawn",
+			accountID: overdrawn.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAccount(gomock.Any(), gomock.Eq(overdrawn.ID)).
+					Times(1).
+					Return(overdrawn, nil)
+
+				expectMember(store, overdrawn.ID, user.Username, db.MemberOwner)
+
+				store.EXPECT().
+					GetHeldAmount(gomock.Any(), gomock.Eq(overdrawn.ID)).
+					Times(1).
+					Return(int64(0), nil)
+			},
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, user.Username, "bearer", time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got AccountResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, int64(30), got.OverdraftInUse)
+				require.Equal(t, int64(70), got.AvailableBalance)
+			},
+		},
+		{
+			name:      "Viewer",
+			accountID: account1.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAccount(gomock.Any(), gomock.Eq(account1.ID)).
+					Times(1).
+					Return(account1, nil)
+
+				expectMember(store, account1.ID, viewer.Username, db.MemberViewer)
+
+				store.EXPECT().
+					GetHeldAmount(gomock.Any(), gomock.Eq(account1.ID)).
+					Times(1).
+					Return(int64(0), nil)
+			},
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, viewer.Username, "bearer", time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:      "NotMember",
+			accountID: account1.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAccount(gomock.Any(), gomock.Eq(account1.ID)).
+					Times(1).
+					Return(account1, nil)
+
+				expectNotMember(store, account1.ID, viewer.Username)
+
+				store.EXPECT().
+					GetHeldAmount(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, req, tokenMaker, viewer.Username, "bearer", time.Minute)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
 			},
 		},
 		{
@@ -125,15 +217,160 @@ func TestGetAccountAPI(t *testing.T) {
 	}
 }
 
+type eqCreateAccountParamsMatcher struct {
+	arg db.CreateAccountParams
+}
+
+// Matches ignores the generated account number as long as its check digits are valid
+func (e eqCreateAccountParamsMatcher) Matches(x any) bool {
+	arg, ok := x.(db.CreateAccountParams)
+	if !ok || !util.IsValidAccountNumber(arg.Number) {
+		return false
+	}
+
+	e.arg.Number = arg.Number
+	return reflect.DeepEqual(e.arg, arg)
+}
+
+func (e eqCreateAccountParamsMatcher) String() string {
+	return fmt.Sprintf("matches arg %v with a valid account number", e.arg)
+}
+
+func EqCreateAccountParams(arg db.CreateAccountParams) gomock.Matcher {
+	return eqCreateAccountParamsMatcher{arg}
+}
+
+func TestCreateAccountAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "DefaultsToChecking",
+			body: gin.H{"owner": user.Username, "currency": util.USD},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateAccountTx(gomock.Any(), EqCreateAccountParams(db.CreateAccountParams{
+						Owner:    user.Username,
+						Currency: util.USD,
+						Kind:     db.AccountChecking,
+					})).
+					Times(1).
+					Return(db.Account{Owner: user.Username, Currency: util.USD, Kind: db.AccountChecking}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "Savings",
+			body: gin.H{"owner": user.Username, "currency": util.USD, "kind": db.AccountSavings},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateAccountTx(gomock.Any(), EqCreateAccountParams(db.CreateAccountParams{
+						Owner:    user.Username,
+						Currency: util.USD,
+						Kind:     db.AccountSavings,
+					})).
+					Times(1).
+					Return(db.Account{Owner: user.Username, Currency: util.USD, Kind: db.AccountSavings}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "NumberTaken",
+			body: gin.H{"owner": user.Username, "currency": util.USD},
+			buildStubs: func(store *mockdb.MockStore) {
+				taken := &pq.Error{Code: "23505", Constraint: accountNumberConstraint}
+				gomock.InOrder(
+					store.EXPECT().CreateAccountTx(gomock.Any(), gomock.Any()).Times(1).Return(db.Account{}, taken),
+					store.EXPECT().CreateAccountTx(gomock.Any(), gomock.Any()).Times(1).Return(db.Account{Owner: user.Username, Currency: util.USD}, nil),
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "DuplicateCurrency",
+			body: gin.H{"owner": user.Username, "currency": util.USD},
+			buildStubs: func(store *mockdb.MockStore) {
+				duplicate := &pq.Error{Code: "23505", Constraint: "unique_owner_currency_kind"}
+				store.EXPECT().CreateAccountTx(gomock.Any(), gomock.Any()).Times(1).Return(db.Account{}, duplicate)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidKind",
+			body: gin.H{"owner": user.Username, "currency": util.USD, "kind": "brokerage"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateAccountTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/accounts", bytes.NewReader(data))
+			addAuthorization(t, req, server.tokenMaker, user.Username, "bearer", time.Minute)
+			server.router.ServeHTTP(recorder, req)
+
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
 func randomAccount(username string) db.Account {
 	return db.Account{
 		ID:       int64(util.RandomInt(1, 1000)),
 		Owner:    username,
 		Currency: util.RandomCurrency(),
 		Balance:  util.RandomMoney(),
+		Status:   db.AccountActive,
+		Kind:     db.AccountChecking,
+		Number:   util.RandomAccountNumber(),
 	}
 }
 
+// expectMember stubs the membership lookup of the user for the account
+func expectMember(store *mockdb.MockStore, accountID int64, username string, role string) {
+	store.EXPECT().
+		GetAccountMember(gomock.Any(), gomock.Eq(db.GetAccountMemberParams{AccountID: accountID, Username: username})).
+		Times(1).
+		Return(db.AccountMember{AccountID: accountID, Username: username, Role: role}, nil)
+}
+
+// expectNotMember stubs the membership lookup of a user that is not a member of the account
+func expectNotMember(store *mockdb.MockStore, accountID int64, username string) {
+	store.EXPECT().
+		GetAccountMember(gomock.Any(), gomock.Eq(db.GetAccountMemberParams{AccountID: accountID, Username: username})).
+		Times(1).
+		Return(db.AccountMember{}, sql.ErrNoRows)
+}
+
 func requireBodyMatcherAccount(t *testing.T, body *httptest.ResponseRecorder, account db.Account) {
 	// t.Helper()
 	bodyResponse, err := io.ReadAll(body.Body)
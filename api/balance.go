@@ -0,0 +1,55 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/haniifac/simplebank/db/sqlc"
+)
+
+type GetAccountBalanceUriParams struct {
+	AccountID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type GetAccountBalanceParams struct {
+	AsOf time.Time `form:"as_of" binding:"required"`
+}
+
+// getAccountBalance returns the balance an account had at a point in time, counting the entries
+// created before as_of. Admins can look up the balance of any account for audits.
+func (server *Server) getAccountBalance(ctx *gin.Context) {
+	var uri GetAccountBalanceUriParams
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	var req GetAccountBalanceParams
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	if req.AsOf.After(time.Now()) {
+		err := errors.New("as_of must not be in the future")
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	if _, valid := server.managedAccount(ctx, uri.AccountID); !valid {
+		return
+	}
+
+	result, err := server.store.BalanceAsOfTx(ctx, db.BalanceAsOfTxParams{
+		AccountID: uri.AccountID,
+		AsOf:      req.AsOf,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
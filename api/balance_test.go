@@ -0,0 +1,163 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/haniifac/simplebank/db/mock"
+	db "github.com/haniifac/simplebank/db/sqlc"
+	"github.com/haniifac/simplebank/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetAccountBalanceAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	other, _ := randomUser(t)
+	admin, _ := randomUser(t)
+	admin.Role = util.AdminRole
+	account := randomAccount(user.Username)
+
+	asOf := time.Date(2026, time.March, 31, 23, 59, 0, 0, time.UTC)
+	takenAt := time.Date(2026, time.March, 31, 0, 0, 0, 0, time.UTC)
+
+	result := db.BalanceAsOfTxResult{
+		AccountID:       account.ID,
+		Currency:        account.Currency,
+		AsOf:            asOf,
+		Balance:         1234,
+		SnapshotTakenAt: &takenAt,
+	}
+
+	testCases := []struct {
+		name          string
+		username      string
+		query         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			username: user.Username,
+			query:    "as_of=2026-03-31T23:59:00Z",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				expectMember(store, account.ID, user.Username, db.MemberViewer)
+				store.EXPECT().
+					BalanceAsOfTx(gomock.Any(), gomock.Eq(db.BalanceAsOfTxParams{AccountID: account.ID, AsOf: asOf})).
+					Times(1).
+					Return(result, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.BalanceAsOfTxResult
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, result.Balance, got.Balance)
+				require.True(t, asOf.Equal(got.AsOf))
+				require.NotNil(t, got.SnapshotTakenAt)
+				require.True(t, takenAt.Equal(*got.SnapshotTakenAt))
+			},
+		},
+		{
+			name:     "Admin",
+			username: admin.Username,
+			query:    "as_of=2026-03-31T23:59:00Z",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				expectNotMember(store, account.ID, admin.Username)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(admin.Username)).Times(1).Return(admin, nil)
+				store.EXPECT().BalanceAsOfTx(gomock.Any(), gomock.Any()).Times(1).Return(result, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:     "NotOwner",
+			username: other.Username,
+			query:    "as_of=2026-03-31T23:59:00Z",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				expectNotMember(store, account.ID, other.Username)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(other.Username)).Times(1).Return(other, nil)
+				store.EXPECT().BalanceAsOfTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:     "MissingAsOf",
+			username: user.Username,
+			query:    "",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().BalanceAsOfTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "FutureAsOf",
+			username: user.Username,
+			query:    "as_of=" + time.Now().UTC().Add(time.Hour).Format(time.RFC3339),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().BalanceAsOfTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "AccountNotFound",
+			username: user.Username,
+			query:    "as_of=2026-03-31T23:59:00Z",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(db.Account{}, sql.ErrNoRows)
+				store.EXPECT().BalanceAsOfTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:     "InternalError",
+			username: user.Username,
+			query:    "as_of=2026-03-31T23:59:00Z",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				expectMember(store, account.ID, user.Username, db.MemberViewer)
+				store.EXPECT().BalanceAsOfTx(gomock.Any(), gomock.Any()).Times(1).Return(db.BalanceAsOfTxResult{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/accounts/%d/balance?%s", account.ID, tc.query)
+			req := httptest.NewRequest(http.MethodGet, url, nil)
+			addAuthorization(t, req, server.tokenMaker, tc.username, "bearer", time.Minute)
+			server.router.ServeHTTP(recorder, req)
+
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	db "github.com/haniifac/simplebank/db/sqlc"
+	"github.com/haniifac/simplebank/token"
+)
+
+type CreateExchangeQuoteParams struct {
+	FromCurrency string `json:"from_currency" binding:"required,currency"`
+	ToCurrency   string `json:"to_currency" binding:"required,currency,nefield=FromCurrency"`
+}
+
+// createExchangeQuote locks the current rate between two currencies for the configured duration
+func (server *Server) createExchangeQuote(ctx *gin.Context) {
+	var req CreateExchangeQuoteParams
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	rate, err := server.store.GetExchangeRate(ctx, db.GetExchangeRateParams{
+		FromCurrency: req.FromCurrency,
+		ToCurrency:   req.ToCurrency,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err := fmt.Errorf("no exchange rate from %s to %s", req.FromCurrency, req.ToCurrency)
+			ctx.JSON(http.StatusNotFound, errResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	id, err := uuid.NewRandom()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	quote, err := server.store.CreateExchangeQuote(ctx, db.CreateExchangeQuoteParams{
+		ID:           id,
+		Username:     authPayload.Username,
+		FromCurrency: rate.FromCurrency,
+		ToCurrency:   rate.ToCurrency,
+		Rate:         rate.Rate,
+		ExpiresAt:    time.Now().Add(server.config.ExchangeQuoteDuration),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, quote)
+}
+
+// ownedExchangeQuote loads a quote of the authenticated user, quotes of other users are reported as not found
+func (server *Server) ownedExchangeQuote(ctx *gin.Context, id uuid.UUID) (db.ExchangeQuote, bool) {
+	quote, err := server.store.GetExchangeQuote(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err := fmt.Errorf("exchange quote %s not found", id)
+			ctx.JSON(http.StatusNotFound, errResponse(err))
+			return quote, false
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return quote, false
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if quote.Username != authPayload.Username {
+		err := fmt.Errorf("exchange quote %s not found", id)
+		ctx.JSON(http.StatusNotFound, errResponse(err))
+		return quote, false
+	}
+
+	return quote, true
+}
@@ -0,0 +1,183 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/haniifac/simplebank/db/sqlc"
+)
+
+type CreateFeeScheduleParams struct {
+	Name            string `json:"name" binding:"required"`
+	Currency        string `json:"currency" binding:"required,currency"`
+	MonthlyFee      int64  `json:"monthly_fee" binding:"min=0"`
+	TransferFee     int64  `json:"transfer_fee" binding:"min=0"`
+	MinimumBalance  int64  `json:"minimum_balance" binding:"min=0"`
+	BelowMinimumFee int64  `json:"below_minimum_fee" binding:"min=0"`
+}
+
+type ListFeeSchedulesParams struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+type FeeAccountUriParams struct {
+	AccountID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type UpdateAccountFeeScheduleParams struct {
+	// FeeScheduleID is null to remove the fee schedule from the account
+	FeeScheduleID *int64 `json:"fee_schedule_id" binding:"omitempty,min=1"`
+}
+
+type ListFeeChargesParams struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+// createFeeSchedule lets admins define a set of fees that can be attached to accounts of its currency
+func (server *Server) createFeeSchedule(ctx *gin.Context) {
+	var req CreateFeeScheduleParams
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	if !server.requireAdmin(ctx, "create fee schedules") {
+		return
+	}
+
+	schedule, err := server.store.CreateFeeSchedule(ctx, db.CreateFeeScheduleParams{
+		Name:            req.Name,
+		Currency:        req.Currency,
+		MonthlyFee:      req.MonthlyFee,
+		TransferFee:     req.TransferFee,
+		MinimumBalance:  req.MinimumBalance,
+		BelowMinimumFee: req.BelowMinimumFee,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, schedule)
+}
+
+// listFeeSchedules returns the fee schedules accounts can be put on
+func (server *Server) listFeeSchedules(ctx *gin.Context) {
+	var req ListFeeSchedulesParams
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	schedules, err := server.store.ListFeeSchedules(ctx, db.ListFeeSchedulesParams{
+		Limit:  req.PageSize,
+		Offset: req.PageSize * (req.PageID - 1),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, schedules)
+}
+
+// updateAccountFeeSchedule lets admins put an account on a fee schedule of the same currency or take it off
+func (server *Server) updateAccountFeeSchedule(ctx *gin.Context) {
+	var uri FeeAccountUriParams
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	var req UpdateAccountFeeScheduleParams
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	if !server.requireAdmin(ctx, "change fee schedules of accounts") {
+		return
+	}
+
+	account, err := server.store.GetAccount(ctx, uri.AccountID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err := fmt.Errorf("account %d not found", uri.AccountID)
+			ctx.JSON(http.StatusNotFound, errResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	var feeScheduleID sql.NullInt64
+	if req.FeeScheduleID != nil {
+		schedule, err := server.store.GetFeeSchedule(ctx, *req.FeeScheduleID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				err := fmt.Errorf("fee schedule %d not found", *req.FeeScheduleID)
+				ctx.JSON(http.StatusNotFound, errResponse(err))
+				return
+			}
+
+			ctx.JSON(http.StatusInternalServerError, errResponse(err))
+			return
+		}
+
+		if schedule.Currency != account.Currency {
+			err := fmt.Errorf("fee schedule %d currency mismatch: %s with %s", schedule.ID, schedule.Currency, account.Currency)
+			ctx.JSON(http.StatusBadRequest, errResponse(err))
+			return
+		}
+
+		feeScheduleID = sql.NullInt64{Int64: schedule.ID, Valid: true}
+	}
+
+	account, err = server.store.UpdateAccountFeeSchedule(ctx, db.UpdateAccountFeeScheduleParams{
+		ID:            account.ID,
+		FeeScheduleID: feeScheduleID,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, account)
+}
+
+// listAccountFees returns the fees charged to an account of the authenticated user, newest first
+func (server *Server) listAccountFees(ctx *gin.Context) {
+	var uri FeeAccountUriParams
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	var req ListFeeChargesParams
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	if _, valid := server.ownedAccount(ctx, uri.AccountID); !valid {
+		return
+	}
+
+	charges, err := server.store.ListFeeCharges(ctx, db.ListFeeChargesParams{
+		AccountID: uri.AccountID,
+		Limit:     req.PageSize,
+		Offset:    req.PageSize * (req.PageID - 1),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, charges)
+}
@@ -0,0 +1,288 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	mockdb "github.com/haniifac/simplebank/db/mock"
+	db "github.com/haniifac/simplebank/db/sqlc"
+	"github.com/haniifac/simplebank/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateFeeScheduleAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	user.Role = util.DepositorRole
+	admin, _ := randomUser(t)
+	admin.Role = util.AdminRole
+
+	body := gin.H{
+		"name":              "basic",
+		"currency":          util.USD,
+		"monthly_fee":       500,
+		"transfer_fee":      25,
+		"minimum_balance":   10000,
+		"below_minimum_fee": 300,
+	}
+
+	testCases := []struct {
+		name          string
+		user          db.User
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			user: admin,
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(admin.Username)).Times(1).Return(admin, nil)
+				store.EXPECT().
+					CreateFeeSchedule(gomock.Any(), gomock.Eq(db.CreateFeeScheduleParams{
+						Name:            "basic",
+						Currency:        util.USD,
+						MonthlyFee:      500,
+						TransferFee:     25,
+						MinimumBalance:  10000,
+						BelowMinimumFee: 300,
+					})).
+					Times(1).
+					Return(db.FeeSchedule{ID: 1, Name: "basic"}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "NotAdmin",
+			user: user,
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().CreateFeeSchedule(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "NegativeFee",
+			user: admin,
+			body: gin.H{"name": "basic", "currency": util.USD, "transfer_fee": -1},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateFeeSchedule(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/fee-schedules", bytes.NewReader(data))
+			addAuthorization(t, req, server.tokenMaker, tc.user.Username, "bearer", time.Minute)
+			server.router.ServeHTTP(recorder, req)
+
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestUpdateAccountFeeScheduleAPI(t *testing.T) {
+	admin, _ := randomUser(t)
+	admin.Role = util.AdminRole
+
+	account := randomAccount(admin.Username)
+	account.Currency = util.USD
+
+	schedule := db.FeeSchedule{ID: 7, Currency: util.USD, TransferFee: 25}
+	eurSchedule := db.FeeSchedule{ID: 8, Currency: util.EUR}
+
+	updated := account
+	updated.FeeScheduleID = sql.NullInt64{Int64: schedule.ID, Valid: true}
+
+	testCases := []struct {
+		name          string
+		body          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Attach",
+			body: `{"fee_schedule_id": 7}`,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(admin.Username)).Times(1).Return(admin, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetFeeSchedule(gomock.Any(), gomock.Eq(schedule.ID)).Times(1).Return(schedule, nil)
+				store.EXPECT().
+					UpdateAccountFeeSchedule(gomock.Any(), gomock.Eq(db.UpdateAccountFeeScheduleParams{
+						ID:            account.ID,
+						FeeScheduleID: updated.FeeScheduleID,
+					})).
+					Times(1).
+					Return(updated, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.Account
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, updated.FeeScheduleID, got.FeeScheduleID)
+			},
+		},
+		{
+			name: "Detach",
+			body: `{"fee_schedule_id": null}`,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(admin.Username)).Times(1).Return(admin, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(updated, nil)
+				store.EXPECT().GetFeeSchedule(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().
+					UpdateAccountFeeSchedule(gomock.Any(), gomock.Eq(db.UpdateAccountFeeScheduleParams{ID: account.ID})).
+					Times(1).
+					Return(account, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "CurrencyMismatch",
+			body: `{"fee_schedule_id": 8}`,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(admin.Username)).Times(1).Return(admin, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetFeeSchedule(gomock.Any(), gomock.Eq(eurSchedule.ID)).Times(1).Return(eurSchedule, nil)
+				store.EXPECT().UpdateAccountFeeSchedule(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "ScheduleNotFound",
+			body: `{"fee_schedule_id": 9}`,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(admin.Username)).Times(1).Return(admin, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetFeeSchedule(gomock.Any(), gomock.Eq(int64(9))).Times(1).Return(db.FeeSchedule{}, sql.ErrNoRows)
+				store.EXPECT().UpdateAccountFeeSchedule(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/accounts/%d/fee-schedule", account.ID)
+			req := httptest.NewRequest(http.MethodPut, url, bytes.NewBufferString(tc.body))
+			addAuthorization(t, req, server.tokenMaker, admin.Username, "bearer", time.Minute)
+			server.router.ServeHTTP(recorder, req)
+
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestListAccountFeesAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	other, _ := randomUser(t)
+	account := randomAccount(user.Username)
+
+	charges := []db.FeeCharge{
+		{ID: 2, AccountID: account.ID, Kind: db.FeeMonthly, Amount: 500},
+		{ID: 1, AccountID: account.ID, Kind: db.FeeTransfer, Amount: 25},
+	}
+
+	testCases := []struct {
+		name          string
+		username      string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				expectMember(store, account.ID, user.Username, db.MemberOwner)
+				store.EXPECT().
+					ListFeeCharges(gomock.Any(), gomock.Eq(db.ListFeeChargesParams{AccountID: account.ID, Limit: 5, Offset: 0})).
+					Times(1).
+					Return(charges, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got []db.FeeCharge
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, charges, got)
+			},
+		},
+		{
+			name:     "NotOwner",
+			username: other.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				expectNotMember(store, account.ID, other.Username)
+				store.EXPECT().ListFeeCharges(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/accounts/%d/fees?page_id=1&page_size=5", account.ID)
+			req := httptest.NewRequest(http.MethodGet, url, nil)
+			addAuthorization(t, req, server.tokenMaker, tc.username, "bearer", time.Minute)
+			server.router.ServeHTTP(recorder, req)
+
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/haniifac/simplebank/db/sqlc"
+)
+
+type HoldAccountUriParams struct {
+	AccountID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type CreateHoldParams struct {
+	ToAccountID int64  `json:"to_account_id" binding:"required,min=1"`
+	Amount      int64  `json:"amount" binding:"required,gt=0"`
+	Description string `json:"description" binding:"max=255"`
+	// ExpiresAt defaults to the configured hold duration from now
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+type HoldUriParams struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type CaptureHoldParams struct {
+	// Amount defaults to the full hold
+	Amount int64 `json:"amount" binding:"min=0"`
+}
+
+// createHold reserves funds on an account of the authenticated user for the receiving account to capture later
+func (server *Server) createHold(ctx *gin.Context) {
+	var uri HoldAccountUriParams
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	var req CreateHoldParams
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	if req.ToAccountID == uri.AccountID {
+		err := errors.New("cannot hold funds for the same account")
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	if !server.requireVerifiedEmail(ctx) {
+		return
+	}
+
+	account, valid := server.ownedAccount(ctx, uri.AccountID, spendingRoles...)
+	if !valid {
+		return
+	}
+
+	if _, valid := server.validAccount(ctx, req.ToAccountID, account.Currency); !valid {
+		return
+	}
+
+	expiresAt := time.Now().Add(server.config.HoldDuration)
+	if req.ExpiresAt != nil {
+		if !req.ExpiresAt.After(time.Now()) {
+			err := errors.New("expires_at must be in the future")
+			ctx.JSON(http.StatusBadRequest, errResponse(err))
+			return
+		}
+		expiresAt = *req.ExpiresAt
+	}
+
+	hold, err := server.store.CreateHoldTx(ctx, db.CreateHoldTxParams{
+		AccountID:   uri.AccountID,
+		ToAccountID: req.ToAccountID,
+		Amount:      req.Amount,
+		Description: req.Description,
+		ExpiresAt:   expiresAt,
+	})
+	if err != nil {
+		server.holdError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, hold)
+}
+
+// listHolds returns the active holds on an account of the authenticated user
+func (server *Server) listHolds(ctx *gin.Context) {
+	var uri HoldAccountUriParams
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	if _, valid := server.ownedAccount(ctx, uri.AccountID); !valid {
+		return
+	}
+
+	holds, err := server.store.ListActiveHolds(ctx, uri.AccountID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, holds)
+}
+
+// captureHold settles a hold into a transfer, which is up to the receiving account
+func (server *Server) captureHold(ctx *gin.Context) {
+	var uri HoldUriParams
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	// the body is optional, an empty one captures the full hold
+	var req CaptureHoldParams
+	if err := ctx.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	if _, valid := server.heldFor(ctx, uri.ID); !valid {
+		return
+	}
+
+	result, err := server.store.CaptureHoldTx(ctx, db.CaptureHoldTxParams{
+		HoldID: uri.ID,
+		Amount: req.Amount,
+	})
+	if err != nil {
+		server.holdError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// releaseHold gives the held funds back to the sending account without a transfer
+func (server *Server) releaseHold(ctx *gin.Context) {
+	var uri HoldUriParams
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	current, valid := server.heldFor(ctx, uri.ID)
+	if !valid {
+		return
+	}
+
+	hold, err := server.store.ResolveHold(ctx, db.ResolveHoldParams{
+		ID:     uri.ID,
+		Status: db.HoldReleased,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err := fmt.Errorf("%w: hold %d is %s", db.ErrHoldNotActive, uri.ID, current.Status)
+			ctx.JSON(http.StatusConflict, errResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, hold)
+}
+
+// heldFor loads a hold the authenticated user may capture or release, which are holds for accounts they may spend
+// from or any hold for admins. The error response is written when the hold cannot be resolved by the user.
+func (server *Server) heldFor(ctx *gin.Context, holdID int64) (db.Hold, bool) {
+	hold, err := server.store.GetHold(ctx, holdID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err := fmt.Errorf("hold %d not found", holdID)
+			ctx.JSON(http.StatusNotFound, errResponse(err))
+			return hold, false
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return hold, false
+	}
+
+	if _, valid := server.managedAccount(ctx, hold.ToAccountID, spendingRoles...); !valid {
+		return hold, false
+	}
+
+	return hold, true
+}
+
+// holdError maps the errors of the hold transactions to a response
+func (server *Server) holdError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		ctx.JSON(http.StatusNotFound, errResponse(err))
+	case errors.Is(err, db.ErrHoldNotActive):
+		ctx.JSON(http.StatusConflict, errResponse(err))
+	case errors.Is(err, db.ErrHoldExpired), errors.Is(err, db.ErrCaptureExceedsHold):
+		ctx.JSON(http.StatusUnprocessableEntity, errResponse(err))
+	default:
+		server.transferError(ctx, err)
+	}
+}
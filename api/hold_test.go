@@ -0,0 +1,361 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	mockdb "github.com/haniifac/simplebank/db/mock"
+	db "github.com/haniifac/simplebank/db/sqlc"
+	"github.com/haniifac/simplebank/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateHoldAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	merchant, _ := randomUser(t)
+
+	account := randomAccount(user.Username)
+	account.Currency = util.USD
+	merchantAccount := randomAccount(merchant.Username)
+	merchantAccount.Currency = util.USD
+	eurAccount := randomAccount(merchant.Username)
+	eurAccount.Currency = util.EUR
+
+	hold := db.Hold{
+		ID:          int64(util.RandomInt(1, 1000)),
+		AccountID:   account.ID,
+		ToAccountID: merchantAccount.ID,
+		Amount:      10,
+		Status:      db.HoldActive,
+	}
+
+	testCases := []struct {
+		name          string
+		username      string
+		requireVerify bool
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			username: user.Username,
+			body:     gin.H{"to_account_id": merchantAccount.ID, "amount": 10, "description": "card payment"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				expectMember(store, account.ID, user.Username, db.MemberOwner)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(merchantAccount.ID)).Times(1).Return(merchantAccount, nil)
+				store.EXPECT().
+					CreateHoldTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.CreateHoldTxParams) (db.Hold, error) {
+						require.Equal(t, account.ID, arg.AccountID)
+						require.Equal(t, merchantAccount.ID, arg.ToAccountID)
+						require.Equal(t, int64(10), arg.Amount)
+						require.Equal(t, "card payment", arg.Description)
+						return hold, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.Hold
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, hold.ID, got.ID)
+			},
+		},
+		{
+			name:          "EmailNotVerified",
+			username:      user.Username,
+			requireVerify: true,
+			body:          gin.H{"to_account_id": merchantAccount.ID, "amount": 10},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateHoldTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+				require.Contains(t, recorder.Body.String(), emailNotVerifiedCode)
+			},
+		},
+		{
+			name:     "InsufficientFunds",
+			username: user.Username,
+			body:     gin.H{"to_account_id": merchantAccount.ID, "amount": 10},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				expectMember(store, account.ID, user.Username, db.MemberOwner)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(merchantAccount.ID)).Times(1).Return(merchantAccount, nil)
+				store.EXPECT().CreateHoldTx(gomock.Any(), gomock.Any()).Times(1).Return(db.Hold{}, db.ErrInsufficientFunds)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+			},
+		},
+		{
+			name:     "CurrencyMismatch",
+			username: user.Username,
+			body:     gin.H{"to_account_id": eurAccount.ID, "amount": 10},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				expectMember(store, account.ID, user.Username, db.MemberOwner)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(eurAccount.ID)).Times(1).Return(eurAccount, nil)
+				store.EXPECT().CreateHoldTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "NotOwner",
+			username: merchant.Username,
+			body:     gin.H{"to_account_id": merchantAccount.ID, "amount": 10},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				expectNotMember(store, account.ID, merchant.Username)
+				store.EXPECT().CreateHoldTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:     "ExpiresInPast",
+			username: user.Username,
+			body:     gin.H{"to_account_id": merchantAccount.ID, "amount": 10, "expires_at": time.Now().Add(-time.Hour)},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				expectMember(store, account.ID, user.Username, db.MemberOwner)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(merchantAccount.ID)).Times(1).Return(merchantAccount, nil)
+				store.EXPECT().CreateHoldTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "InvalidAmount",
+			username: user.Username,
+			body:     gin.H{"to_account_id": merchantAccount.ID, "amount": -1},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateHoldTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			server.config.RequireVerifiedEmail = tc.requireVerify
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/accounts/%d/holds", account.ID)
+			req := httptest.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			addAuthorization(t, req, server.tokenMaker, tc.username, "bearer", time.Minute)
+			server.router.ServeHTTP(recorder, req)
+
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestResolveHoldAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	user.Role = util.DepositorRole
+	merchant, _ := randomUser(t)
+
+	account := randomAccount(user.Username)
+	merchantAccount := randomAccount(merchant.Username)
+
+	hold := db.Hold{
+		ID:          int64(util.RandomInt(1, 1000)),
+		AccountID:   account.ID,
+		ToAccountID: merchantAccount.ID,
+		Amount:      10,
+		Status:      db.HoldActive,
+	}
+
+	captured := hold
+	captured.Status = db.HoldCaptured
+	captured.CapturedAmount = sql.NullInt64{Int64: 6, Valid: true}
+
+	released := hold
+	released.Status = db.HoldReleased
+
+	testCases := []struct {
+		name          string
+		action        string
+		username      string
+		body          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "CapturePartial",
+			action:   "capture",
+			username: merchant.Username,
+			body:     `{"amount": 6}`,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetHold(gomock.Any(), gomock.Eq(hold.ID)).Times(1).Return(hold, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(merchantAccount.ID)).Times(1).Return(merchantAccount, nil)
+				expectMember(store, merchantAccount.ID, merchant.Username, db.MemberOwner)
+				store.EXPECT().
+					CaptureHoldTx(gomock.Any(), gomock.Eq(db.CaptureHoldTxParams{HoldID: hold.ID, Amount: 6})).
+					Times(1).
+					Return(db.CaptureHoldTxResult{Hold: captured}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:     "CaptureFullWithoutBody",
+			action:   "capture",
+			username: merchant.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetHold(gomock.Any(), gomock.Eq(hold.ID)).Times(1).Return(hold, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(merchantAccount.ID)).Times(1).Return(merchantAccount, nil)
+				expectMember(store, merchantAccount.ID, merchant.Username, db.MemberOwner)
+				store.EXPECT().
+					CaptureHoldTx(gomock.Any(), gomock.Eq(db.CaptureHoldTxParams{HoldID: hold.ID})).
+					Times(1).
+					Return(db.CaptureHoldTxResult{Hold: captured}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:     "CaptureExpired",
+			action:   "capture",
+			username: merchant.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetHold(gomock.Any(), gomock.Eq(hold.ID)).Times(1).Return(hold, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(merchantAccount.ID)).Times(1).Return(merchantAccount, nil)
+				expectMember(store, merchantAccount.ID, merchant.Username, db.MemberOwner)
+				store.EXPECT().CaptureHoldTx(gomock.Any(), gomock.Any()).Times(1).Return(db.CaptureHoldTxResult{}, db.ErrHoldExpired)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+			},
+		},
+		{
+			name:     "CaptureNotActive",
+			action:   "capture",
+			username: merchant.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetHold(gomock.Any(), gomock.Eq(hold.ID)).Times(1).Return(released, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(merchantAccount.ID)).Times(1).Return(merchantAccount, nil)
+				expectMember(store, merchantAccount.ID, merchant.Username, db.MemberOwner)
+				store.EXPECT().CaptureHoldTx(gomock.Any(), gomock.Any()).Times(1).Return(db.CaptureHoldTxResult{}, db.ErrHoldNotActive)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:     "CaptureBySender",
+			action:   "capture",
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetHold(gomock.Any(), gomock.Eq(hold.ID)).Times(1).Return(hold, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(merchantAccount.ID)).Times(1).Return(merchantAccount, nil)
+				expectNotMember(store, merchantAccount.ID, user.Username)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().CaptureHoldTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:     "Release",
+			action:   "release",
+			username: merchant.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetHold(gomock.Any(), gomock.Eq(hold.ID)).Times(1).Return(hold, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(merchantAccount.ID)).Times(1).Return(merchantAccount, nil)
+				expectMember(store, merchantAccount.ID, merchant.Username, db.MemberOwner)
+				store.EXPECT().
+					ResolveHold(gomock.Any(), gomock.Eq(db.ResolveHoldParams{ID: hold.ID, Status: db.HoldReleased})).
+					Times(1).
+					Return(released, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.Hold
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, db.HoldReleased, got.Status)
+			},
+		},
+		{
+			name:     "ReleaseNotActive",
+			action:   "release",
+			username: merchant.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetHold(gomock.Any(), gomock.Eq(hold.ID)).Times(1).Return(captured, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(merchantAccount.ID)).Times(1).Return(merchantAccount, nil)
+				expectMember(store, merchantAccount.ID, merchant.Username, db.MemberOwner)
+				store.EXPECT().ResolveHold(gomock.Any(), gomock.Any()).Times(1).Return(db.Hold{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:     "NotFound",
+			action:   "release",
+			username: merchant.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetHold(gomock.Any(), gomock.Eq(hold.ID)).Times(1).Return(db.Hold{}, sql.ErrNoRows)
+				store.EXPECT().ResolveHold(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/holds/%d/%s", hold.ID, tc.action)
+			req := httptest.NewRequest(http.MethodPost, url, bytes.NewBufferString(tc.body))
+			addAuthorization(t, req, server.tokenMaker, tc.username, "bearer", time.Minute)
+			server.router.ServeHTTP(recorder, req)
+
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
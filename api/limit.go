@@ -0,0 +1,84 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/haniifac/simplebank/db/sqlc"
+)
+
+type AccountLimitsUriParams struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// UpdateAccountLimitsParams sets the outgoing limits of an account, a missing or null limit
+// makes the account use the configured default again
+type UpdateAccountLimitsParams struct {
+	DailyLimit   *int64 `json:"daily_limit" binding:"omitempty,min=0"`
+	MonthlyLimit *int64 `json:"monthly_limit" binding:"omitempty,min=0"`
+}
+
+// getAccountLimits shows how much of its daily and monthly outgoing limits an account used and has left
+func (server *Server) getAccountLimits(ctx *gin.Context) {
+	var uri AccountLimitsUriParams
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	if _, valid := server.managedAccount(ctx, uri.ID); !valid {
+		return
+	}
+
+	limits, err := server.store.AccountLimitsTx(ctx, uri.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, limits)
+}
+
+// updateAccountLimits lets admins give an account its own outgoing limits
+func (server *Server) updateAccountLimits(ctx *gin.Context) {
+	var uri AccountLimitsUriParams
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	var req UpdateAccountLimitsParams
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	if !server.requireAdmin(ctx, "change transfer limits") {
+		return
+	}
+
+	arg := db.UpdateAccountTransferLimitsParams{ID: uri.ID}
+	if req.DailyLimit != nil {
+		arg.DailyLimit = sql.NullInt64{Int64: *req.DailyLimit, Valid: true}
+	}
+	if req.MonthlyLimit != nil {
+		arg.MonthlyLimit = sql.NullInt64{Int64: *req.MonthlyLimit, Valid: true}
+	}
+
+	account, err := server.store.UpdateAccountTransferLimits(ctx, arg)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err := fmt.Errorf("account %d not found", uri.ID)
+			ctx.JSON(http.StatusNotFound, errResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, account)
+}
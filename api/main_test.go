@@ -1,14 +1,21 @@
 package api
 
 import (
+	"context"
+	"database/sql"
 	"os"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	mockdb "github.com/haniifac/simplebank/db/mock"
 	db "github.com/haniifac/simplebank/db/sqlc"
+	"github.com/haniifac/simplebank/mail"
 	"github.com/haniifac/simplebank/util"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
 )
 
 func TestMain(m *testing.M) {
@@ -25,6 +32,44 @@ func newTestServer(t *testing.T, store db.Store) *Server {
 
 	server, err := NewServer(config, store)
 	require.NoError(t, err)
+	server.mailer = &testMailer{}
+
+	if mockStore, ok := store.(*mockdb.MockStore); ok {
+		expectTestSessions(mockStore)
+	}
 
 	return server
 }
+
+// testSessions keeps the user of every session addAuthorization created an access token for
+var testSessions sync.Map
+
+// expectTestSessions lets the auth middleware find the sessions of the access tokens added by addAuthorization,
+// session lookups the test expects itself are matched first
+func expectTestSessions(store *mockdb.MockStore) {
+	store.EXPECT().
+		GetAuthSession(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		DoAndReturn(func(_ context.Context, id uuid.UUID) (db.GetAuthSessionRow, error) {
+			username, ok := testSessions.Load(id)
+			if !ok {
+				return db.GetAuthSessionRow{}, sql.ErrNoRows
+			}
+
+			return db.GetAuthSessionRow{ID: id, Username: username.(string)}, nil
+		})
+}
+
+// testMailer keeps the emails sent during a test, or fails with err when it is set
+type testMailer struct {
+	emails []mail.Email
+	err    error
+}
+
+func (mailer *testMailer) Send(ctx context.Context, email mail.Email) error {
+	if mailer.err != nil {
+		return mailer.err
+	}
+	mailer.emails = append(mailer.emails, email)
+	return nil
+}
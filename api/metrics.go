@@ -0,0 +1,16 @@
+package api
+
+import (
+	"expvar"
+
+	"github.com/gin-gonic/gin"
+)
+
+// getMetrics serves the expvar metrics, such as the transaction retry counters, to admins
+func (server *Server) getMetrics(ctx *gin.Context) {
+	if !server.requireAdmin(ctx, "read metrics") {
+		return
+	}
+
+	expvar.Handler().ServeHTTP(ctx.Writer, ctx.Request)
+}
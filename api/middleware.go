@@ -1,12 +1,15 @@
 package api
 
 import (
+	"database/sql"
 	"errors"
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/haniifac/simplebank/token"
 )
 
@@ -44,9 +47,53 @@ func (server *Server) authMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
 		if err != nil {
 			err := fmt.Errorf("verify token failed: %v", err)
 			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errResponse(err))
+			return
+		}
+
+		// access tokens handed out by login and refresh always belong to a session, refresh tokens never do
+		if payload.SessionID == uuid.Nil {
+			err := errors.New("token does not belong to a session")
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errResponse(err))
+			return
+		}
+
+		if !server.activeSession(ctx, payload) {
+			return
 		}
 
 		ctx.Set(authorizationPayloadKey, payload)
 		ctx.Next()
 	}
 }
+
+// activeSession checks the login session an access token belongs to was not blocked by a logout, and that
+// the token was not issued before the password of the user changed, aborting the request when it was.
+// Every token accepted by authMiddleware goes through it, since tokens without a session are refused
+func (server *Server) activeSession(ctx *gin.Context, payload *token.Payload) bool {
+	session, err := server.store.GetAuthSession(ctx, payload.SessionID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err := errors.New("session not found")
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errResponse(err))
+			return false
+		}
+
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, errResponse(err))
+		return false
+	}
+
+	if session.IsBlocked || session.Username != payload.Username {
+		err := errors.New("session is blocked")
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, errResponse(err))
+		return false
+	}
+
+	// tokens only keep the issue time in seconds, a token without one cannot prove it was issued after the change
+	if payload.IssuedAt == nil || payload.IssuedAt.Time.Before(session.PasswordUpdatedAt.Truncate(time.Second)) {
+		err := errors.New("token was issued before the password was changed")
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, errResponse(err))
+		return false
+	}
+
+	return true
+}
@@ -1,6 +1,8 @@
 package api
 
 import (
+	"context"
+	"database/sql"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -8,8 +10,12 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	mockdb "github.com/haniifac/simplebank/db/mock"
+	db "github.com/haniifac/simplebank/db/sqlc"
 	"github.com/haniifac/simplebank/token"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
 )
 
 func addAuthorization(
@@ -20,7 +26,10 @@ func addAuthorization(
 	tokenType string,
 	tokenDuration time.Duration,
 ) {
-	token, payload, err := tokenMaker.CreateToken(username, tokenDuration)
+	sessionID := uuid.New()
+	testSessions.Store(sessionID, username)
+
+	token, payload, err := tokenMaker.CreateSessionToken(username, sessionID, tokenDuration)
 	require.NoError(t, err)
 	require.NotEmpty(t, token)
 	require.NotEmpty(t, payload)
@@ -105,11 +114,26 @@ func TestMiddlewareAuthentication(t *testing.T) {
 				require.Contains(t, string(responseBody), token.ErrInvalidToken.Error())
 			},
 		},
+		{
+			name: "RefreshToken",
+			setupAuth: func(t *testing.T, req *http.Request, tokenMaker token.Maker) {
+				refreshToken, _, err := tokenMaker.CreateToken("user", time.Minute)
+				require.NoError(t, err)
+
+				req.Header.Set(authHeaderKey, authTypeBearer+" "+refreshToken)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			server := newTestServer(t, nil)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			server := newTestServer(t, mockdb.NewMockStore(ctrl))
 
 			authPath := "/auth"
 			server.router.GET(
@@ -131,3 +155,152 @@ func TestMiddlewareAuthentication(t *testing.T) {
 		})
 	}
 }
+
+// addSessionAuthorization adds an access token belonging to the login session to the request
+func addSessionAuthorization(t *testing.T, req *http.Request, tokenMaker token.Maker, username string, sessionID uuid.UUID) {
+	token, _, err := tokenMaker.CreateSessionToken(username, sessionID, time.Minute)
+	require.NoError(t, err)
+
+	req.Header.Set(authHeaderKey, authTypeBearer+" "+token)
+}
+
+// expectActiveSession stubs the session lookup of the middleware for an access token of the session
+func expectActiveSession(store *mockdb.MockStore, session db.Session) {
+	store.EXPECT().
+		GetAuthSession(gomock.Any(), gomock.Eq(session.ID)).
+		Times(1).
+		Return(db.GetAuthSessionRow{ID: session.ID, Username: session.Username, IsBlocked: session.IsBlocked}, nil)
+}
+
+func TestMiddlewareSession(t *testing.T) {
+	session := db.GetAuthSessionRow{
+		ID:       uuid.New(),
+		Username: "user",
+	}
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAuthSession(gomock.Any(), gomock.Eq(session.ID)).Times(1).Return(session, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "Blocked",
+			buildStubs: func(store *mockdb.MockStore) {
+				blocked := session
+				blocked.IsBlocked = true
+				store.EXPECT().GetAuthSession(gomock.Any(), gomock.Eq(session.ID)).Times(1).Return(blocked, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "PasswordChanged",
+			buildStubs: func(store *mockdb.MockStore) {
+				changed := session
+				changed.PasswordUpdatedAt = time.Now().Add(time.Minute)
+				store.EXPECT().GetAuthSession(gomock.Any(), gomock.Eq(session.ID)).Times(1).Return(changed, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAuthSession(gomock.Any(), gomock.Eq(session.ID)).Times(1).Return(db.GetAuthSessionRow{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+
+			authPath := "/auth"
+			server.router.GET(
+				authPath,
+				server.authMiddleware(server.tokenMaker),
+				func(ctx *gin.Context) {
+					ctx.JSON(http.StatusOK, gin.H{})
+				},
+			)
+
+			recorder := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, authPath, nil)
+			addSessionAuthorization(t, req, server.tokenMaker, session.Username, session.ID)
+			server.router.ServeHTTP(recorder, req)
+
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestMiddlewareRefreshTokenAfterLogout(t *testing.T) {
+	user, _ := randomUser(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	session := randomSession(user.Username)
+
+	blocked := false
+	store.EXPECT().
+		GetAuthSession(gomock.Any(), gomock.Eq(session.ID)).
+		AnyTimes().
+		DoAndReturn(func(_ context.Context, id uuid.UUID) (db.GetAuthSessionRow, error) {
+			return db.GetAuthSessionRow{ID: id, Username: user.Username, IsBlocked: blocked}, nil
+		})
+	store.EXPECT().GetSession(gomock.Any(), gomock.Eq(session.ID)).Times(1).Return(session, nil)
+	store.EXPECT().
+		BlockSessionFamily(gomock.Any(), gomock.Eq(session.FamilyID)).
+		Times(1).
+		DoAndReturn(func(_ context.Context, _ uuid.UUID) error {
+			blocked = true
+			return nil
+		})
+	store.EXPECT().ListAccounts(gomock.Any(), gomock.Any()).Times(0)
+
+	server := newTestServer(t, store)
+
+	// login hands out refresh tokens alongside the access tokens of the session, without a session id of their own
+	refreshToken, _, err := server.tokenMaker.CreateToken(user.Username, time.Hour)
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/users/logout", nil)
+	addSessionAuthorization(t, req, server.tokenMaker, user.Username, session.ID)
+	server.router.ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodGet, "/accounts?page_id=1&page_size=5", nil)
+	req.Header.Set(authHeaderKey, authTypeBearer+" "+refreshToken)
+	server.router.ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusUnauthorized, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodGet, "/accounts?page_id=1&page_size=5", nil)
+	addSessionAuthorization(t, req, server.tokenMaker, user.Username, session.ID)
+	server.router.ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusUnauthorized, recorder.Code)
+}
@@ -0,0 +1,63 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/haniifac/simplebank/db/sqlc"
+	"github.com/lib/pq"
+)
+
+type OverdraftLimitUriParams struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type UpdateOverdraftLimitParams struct {
+	OverdraftLimit *int64 `json:"overdraft_limit" binding:"required,min=0"`
+}
+
+// updateOverdraftLimit lets admins change how far below zero an account may go.
+// The limit cannot be lowered below the overdraft the account is already using.
+func (server *Server) updateOverdraftLimit(ctx *gin.Context) {
+	var uri OverdraftLimitUriParams
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	var req UpdateOverdraftLimitParams
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	if !server.requireAdmin(ctx, "change overdraft limits") {
+		return
+	}
+
+	account, err := server.store.UpdateAccountOverdraftLimit(ctx, db.UpdateAccountOverdraftLimitParams{
+		ID:             uri.ID,
+		OverdraftLimit: *req.OverdraftLimit,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err := fmt.Errorf("account %d not found", uri.ID)
+			ctx.JSON(http.StatusNotFound, errResponse(err))
+			return
+		}
+
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "check_violation" {
+			err := fmt.Errorf("account %d uses more overdraft than %d", uri.ID, *req.OverdraftLimit)
+			ctx.JSON(http.StatusUnprocessableEntity, errResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, account)
+}
@@ -0,0 +1,115 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/haniifac/simplebank/db/sqlc"
+	"github.com/haniifac/simplebank/mail"
+	"github.com/haniifac/simplebank/util"
+)
+
+// defaultPasswordResetTokenDuration is used when no token duration is configured
+const defaultPasswordResetTokenDuration = 15 * time.Minute
+
+type RequestPasswordResetRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type ConfirmPasswordResetRequest struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required,min=6"`
+}
+
+// requestPasswordReset emails a single use password reset token to the user with the given email.
+// The response is the same whether or not a user has the email, so it cannot be used to find out
+// who has an account.
+func (server *Server) requestPasswordReset(ctx *gin.Context) {
+	var req RequestPasswordResetRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	user, err := server.store.GetUserByEmail(ctx, req.Email)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusOK, gin.H{})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	resetToken, err := util.NewSecret()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	duration := server.config.PasswordResetTokenDuration
+	if duration <= 0 {
+		duration = defaultPasswordResetTokenDuration
+	}
+
+	_, err = server.store.CreatePasswordResetToken(ctx, db.CreatePasswordResetTokenParams{
+		Username:  user.Username,
+		TokenHash: util.HashSecret(resetToken),
+		ExpiresAt: time.Now().Add(duration),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	err = server.mailer.Send(ctx, mail.Email{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hi %s,\n\nUse this token to reset your password, it can only be used once and expires in %s:\n\n%s\n\n"+
+			"If you did not ask to reset your password you can ignore this email.\n", user.Fullname, duration, resetToken),
+	})
+	// the response must not differ from the one for an unknown email, the user can ask again
+	if err != nil {
+		log.Printf("cannot send password reset email to %s: %v", user.Username, err)
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{})
+}
+
+// confirmPasswordReset sets a new password with a token from a password reset email.
+// Like a password change it logs the user out of every device.
+func (server *Server) confirmPasswordReset(ctx *gin.Context) {
+	var req ConfirmPasswordResetRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	hashedPassword, err := util.HashPassword(req.NewPassword)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	user, err := server.store.ResetPasswordTx(ctx, db.ResetPasswordTxParams{
+		TokenHash:      util.HashSecret(req.Token),
+		HashedPassword: hashedPassword,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrResetTokenInvalid) {
+			ctx.JSON(http.StatusBadRequest, errResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, castUserResponse(user))
+}
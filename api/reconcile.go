@@ -0,0 +1,30 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// getLatestReconciliation returns the report of the last ledger reconciliation to admins
+func (server *Server) getLatestReconciliation(ctx *gin.Context) {
+	if !server.requireAdmin(ctx, "read reconciliation reports") {
+		return
+	}
+
+	report, err := server.store.GetLatestReconciliationReport(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err := errors.New("the ledger has not been reconciled yet")
+			ctx.JSON(http.StatusNotFound, errResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
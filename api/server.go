@@ -7,6 +7,7 @@ import (
 	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
 	db "github.com/haniifac/simplebank/db/sqlc"
+	"github.com/haniifac/simplebank/mail"
 	"github.com/haniifac/simplebank/token"
 	"github.com/haniifac/simplebank/util"
 )
@@ -15,6 +16,7 @@ type Server struct {
 	config     util.Config
 	store      db.Store
 	tokenMaker token.Maker
+	mailer     mail.Mailer
 	router     *gin.Engine
 }
 
@@ -28,10 +30,13 @@ func NewServer(config util.Config, store db.Store) (*Server, error) {
 		config:     config,
 		store:      store,
 		tokenMaker: tokenMaker,
+		mailer:     mail.NewMailer(config),
 	}
 
 	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
 		v.RegisterValidation("currency", validCurrency)
+		v.RegisterValidation("frequency", validFrequency)
+		v.RegisterValidation("account_number", validAccountNumber)
 	}
 
 	server.setRouter()
@@ -47,12 +52,62 @@ func (server *Server) setRouter() {
 	authGroup.POST("/accounts", server.createAccount)
 	authGroup.GET("/accounts", server.listAccounts)
 	authGroup.GET("/accounts/:id", server.getAccount)
+	authGroup.GET("/accounts/:id/balance", server.getAccountBalance)
+	authGroup.GET("/accounts/:id/transfers", server.listAccountTransfers)
+	authGroup.GET("/accounts/:id/statement", server.getAccountStatement)
+	authGroup.POST("/accounts/:id/freeze", server.freezeAccount)
+	authGroup.POST("/accounts/:id/unfreeze", server.unfreezeAccount)
+	authGroup.POST("/accounts/:id/close", server.closeAccount)
+	authGroup.PUT("/accounts/:id/overdraft-limit", server.updateOverdraftLimit)
+	authGroup.GET("/accounts/:id/limits", server.getAccountLimits)
+	authGroup.PUT("/accounts/:id/limits", server.updateAccountLimits)
+	authGroup.PUT("/accounts/:id/fee-schedule", server.updateAccountFeeSchedule)
+	authGroup.GET("/accounts/:id/fees", server.listAccountFees)
+	authGroup.POST("/accounts/:id/holds", server.createHold)
+	authGroup.GET("/accounts/:id/holds", server.listHolds)
+	authGroup.GET("/accounts/:id/members", server.listAccountMembers)
+	authGroup.POST("/accounts/:id/members", server.inviteAccountMember)
+	authGroup.DELETE("/accounts/:id/members/:username", server.removeAccountMember)
 	authGroup.POST("/transfers", server.createTransfer)
+	authGroup.GET("/transfers/:id", server.getTransfer)
+	authGroup.POST("/transfers/:id/reverse", server.reverseTransfer)
+	authGroup.POST("/transfers/scheduled", server.createScheduledTransfer)
+	authGroup.GET("/transfers/scheduled", server.listScheduledTransfers)
+	authGroup.POST("/transfers/scheduled/:id/cancel", server.cancelScheduledTransfer)
+
+	authGroup.POST("/exchange-quotes", server.createExchangeQuote)
+
+	authGroup.POST("/fee-schedules", server.createFeeSchedule)
+	authGroup.GET("/fee-schedules", server.listFeeSchedules)
+
+	authGroup.POST("/holds/:id/capture", server.captureHold)
+	authGroup.POST("/holds/:id/release", server.releaseHold)
+
+	authGroup.POST("/standing-orders", server.createStandingOrder)
+	authGroup.GET("/standing-orders", server.listStandingOrders)
+	authGroup.GET("/standing-orders/:id", server.getStandingOrder)
+	authGroup.PUT("/standing-orders/:id", server.updateStandingOrder)
+	authGroup.DELETE("/standing-orders/:id", server.cancelStandingOrder)
+	authGroup.GET("/standing-orders/:id/executions", server.listStandingOrderExecutions)
+
+	authGroup.GET("/reconciliations/latest", server.getLatestReconciliation)
 
 	authGroup.GET("users/:username", server.GetUser)
+	authGroup.PUT("/users/:username/password", server.changePassword)
+	authGroup.POST("/users/logout", server.logoutUser)
+	authGroup.POST("/users/logout/all", server.logoutEverywhere)
+	authGroup.POST("/users/verify-email/resend", server.resendVerificationEmail)
+
+	authGroup.GET("/sessions", server.listSessions)
+	authGroup.DELETE("/sessions/:id", server.revokeSession)
+
+	authGroup.GET("/debug/vars", server.getMetrics)
 
 	router.POST("/users", server.CreateUser)
 	router.POST("/users/login", server.loginUser)
+	router.POST("/users/password-reset", server.requestPasswordReset)
+	router.POST("/users/password-reset/confirm", server.confirmPasswordReset)
+	router.GET("/users/verify-email", server.verifyEmail)
 	router.POST("/tokens/refresh", server.renewAccessToken)
 
 	server.router = router
@@ -63,6 +118,21 @@ func (server *Server) Start(address string) error {
 	return err
 }
 
+// error codes for failures clients are expected to handle
+const (
+	accountNotActiveCode      = "account_not_active"
+	transferLimitExceededCode = "transfer_limit_exceeded"
+	emailNotVerifiedCode      = "email_not_verified"
+)
+
+// errCodeResponse adds a machine readable code to the error response
+func errCodeResponse(code string, err error) gin.H {
+	return gin.H{
+		"error": err.Error(),
+		"code":  code,
+	}
+}
+
 func errResponse(err error) gin.H {
 	return gin.H{
 		"error": err.Error(),
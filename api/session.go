@@ -0,0 +1,150 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	db "github.com/haniifac/simplebank/db/sqlc"
+	"github.com/haniifac/simplebank/token"
+)
+
+type SessionUriParams struct {
+	ID string `uri:"id" binding:"required,uuid"`
+}
+
+// sessionResponse describes a logged in device without exposing its refresh token
+type sessionResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Device    string    `json:"device"`
+	UserAgent string    `json:"user_agent"`
+	IpAddress string    `json:"ip_address"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+	// Current is set for the session the request was made with
+	Current bool `json:"current"`
+}
+
+func castSessionResponse(session db.Session, payload *token.Payload) sessionResponse {
+	return sessionResponse{
+		ID:        session.ID,
+		Device:    deviceName(session.UserAgent),
+		UserAgent: session.UserAgent,
+		IpAddress: session.IpAddress,
+		CreatedAt: session.CreatedAt,
+		ExpiresAt: session.ExpiresAt,
+		Current:   session.ID == payload.SessionID,
+	}
+}
+
+// deviceName makes a rough guess of the device a session was started on from its user agent
+func deviceName(userAgent string) string {
+	devices := []struct {
+		token string
+		name  string
+	}{
+		{"iPhone", "iPhone"},
+		{"iPad", "iPad"},
+		{"Android", "Android"},
+		{"Windows", "Windows"},
+		{"Macintosh", "Mac"},
+		{"Linux", "Linux"},
+	}
+
+	for _, device := range devices {
+		if strings.Contains(userAgent, device.token) {
+			return device.name
+		}
+	}
+	return "unknown"
+}
+
+// listSessions returns the sessions of the authenticated user that can still be refreshed, one per logged in device
+func (server *Server) listSessions(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	sessions, err := server.store.ListActiveSessions(ctx, authPayload.Username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	res := make([]sessionResponse, len(sessions))
+	for i, session := range sessions {
+		res[i] = castSessionResponse(session, authPayload)
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// revokeSession logs out the device of a session of the authenticated user
+func (server *Server) revokeSession(ctx *gin.Context) {
+	var uri SessionUriParams
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	session, err := server.store.GetSession(ctx, uuid.MustParse(uri.ID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err := fmt.Errorf("session %s not found", uri.ID)
+			ctx.JSON(http.StatusNotFound, errResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	if session.Username != authPayload.Username {
+		err := fmt.Errorf("session %s does not belong to the authenticated user", uri.ID)
+		ctx.JSON(http.StatusForbidden, errResponse(err))
+		return
+	}
+
+	if err := server.store.BlockSessionFamily(ctx, session.FamilyID); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	session.IsBlocked = true
+	ctx.JSON(http.StatusOK, castSessionResponse(session, authPayload))
+}
+
+// logoutUser blocks the session the request was made with, so neither its access nor its refresh tokens work anymore
+func (server *Server) logoutUser(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	session, err := server.store.GetSession(ctx, authPayload.SessionID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	if err := server.store.BlockSessionFamily(ctx, session.FamilyID); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{})
+}
+
+// logoutEverywhere blocks every session of the authenticated user
+func (server *Server) logoutEverywhere(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	if err := server.store.BlockUserSessions(ctx, authPayload.Username); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{})
+}
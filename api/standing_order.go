@@ -0,0 +1,280 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/haniifac/simplebank/db/sqlc"
+	"github.com/haniifac/simplebank/token"
+	"github.com/haniifac/simplebank/util"
+)
+
+type CreateStandingOrderParams struct {
+	FromAccountID           int64      `json:"from_account_id" binding:"required,min=1"`
+	ToAccountID             int64      `json:"to_account_id" binding:"required,min=1"`
+	Amount                  int64      `json:"amount" binding:"required,gt=0"`
+	Currency                string     `json:"currency" binding:"required,currency"`
+	Frequency               string     `json:"frequency" binding:"required,frequency"`
+	DayOfMonth              int32      `json:"day_of_month" binding:"omitempty,min=1,max=31"`
+	InsufficientFundsPolicy string     `json:"insufficient_funds_policy" binding:"omitempty,oneof=skip retry"`
+	FirstRunAt              time.Time  `json:"first_run_at" binding:"required"`
+	EndDate                 *time.Time `json:"end_date"`
+	Count                   *int32     `json:"count" binding:"omitempty,min=1"`
+}
+
+type UpdateStandingOrderParams struct {
+	Amount                  int64      `json:"amount" binding:"required,gt=0"`
+	InsufficientFundsPolicy string     `json:"insufficient_funds_policy" binding:"required,oneof=skip retry"`
+	EndDate                 *time.Time `json:"end_date"`
+}
+
+type StandingOrderUriParams struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type ListStandingOrdersParams struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+func (server *Server) createStandingOrder(ctx *gin.Context) {
+	var req CreateStandingOrderParams
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	if !server.requireVerifiedEmail(ctx) {
+		return
+	}
+
+	if req.Frequency == util.Monthly && req.DayOfMonth == 0 {
+		err := errors.New("day_of_month is required for monthly standing orders")
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	if !req.FirstRunAt.After(time.Now()) {
+		err := errors.New("first_run_at must be in the future")
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	if req.EndDate != nil && req.EndDate.Before(req.FirstRunAt) {
+		err := errors.New("end_date must not be before first_run_at")
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	fromAccount, valid := server.validAccount(ctx, req.FromAccountID, req.Currency)
+	if !valid {
+		return
+	}
+
+	if !server.requireAccountRole(ctx, fromAccount.ID, spendingRoles...) {
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	_, valid = server.validAccount(ctx, req.ToAccountID, req.Currency)
+	if !valid {
+		return
+	}
+
+	arg := db.CreateStandingOrderParams{
+		Owner:                   authPayload.Username,
+		FromAccountID:           req.FromAccountID,
+		ToAccountID:             req.ToAccountID,
+		Amount:                  req.Amount,
+		Frequency:               req.Frequency,
+		DayOfMonth:              req.DayOfMonth,
+		InsufficientFundsPolicy: db.InsufficientFundsSkip,
+		NextRunAt:               req.FirstRunAt,
+	}
+	if req.InsufficientFundsPolicy != "" {
+		arg.InsufficientFundsPolicy = req.InsufficientFundsPolicy
+	}
+	if req.EndDate != nil {
+		arg.EndDate = sql.NullTime{Time: *req.EndDate, Valid: true}
+	}
+	if req.Count != nil {
+		arg.RemainingCount = sql.NullInt32{Int32: *req.Count, Valid: true}
+	}
+
+	order, err := server.store.CreateStandingOrder(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, order)
+}
+
+func (server *Server) getStandingOrder(ctx *gin.Context) {
+	var req StandingOrderUriParams
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	order, valid := server.ownedStandingOrder(ctx, req.ID)
+	if !valid {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, order)
+}
+
+func (server *Server) listStandingOrders(ctx *gin.Context) {
+	var req ListStandingOrdersParams
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	orders, err := server.store.ListStandingOrders(ctx, db.ListStandingOrdersParams{
+		Owner:  authPayload.Username,
+		Limit:  req.PageSize,
+		Offset: req.PageSize * (req.PageID - 1),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, orders)
+}
+
+func (server *Server) updateStandingOrder(ctx *gin.Context) {
+	var uri StandingOrderUriParams
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	var req UpdateStandingOrderParams
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	if _, valid := server.ownedStandingOrder(ctx, uri.ID); !valid {
+		return
+	}
+
+	arg := db.UpdateStandingOrderParams{
+		ID:                      uri.ID,
+		Amount:                  req.Amount,
+		InsufficientFundsPolicy: req.InsufficientFundsPolicy,
+	}
+	if req.EndDate != nil {
+		arg.EndDate = sql.NullTime{Time: *req.EndDate, Valid: true}
+	}
+
+	order, err := server.store.UpdateStandingOrder(ctx, arg)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			err := fmt.Errorf("standing order %d is no longer active", uri.ID)
+			ctx.JSON(http.StatusConflict, errResponse(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, order)
+}
+
+func (server *Server) cancelStandingOrder(ctx *gin.Context) {
+	var req StandingOrderUriParams
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	// the owners of the account can stop orders other members set up on it
+	if _, valid := server.ownedStandingOrder(ctx, req.ID, db.MemberOwner); !valid {
+		return
+	}
+
+	order, err := server.store.CancelStandingOrder(ctx, req.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			err := fmt.Errorf("standing order %d is no longer active", req.ID)
+			ctx.JSON(http.StatusConflict, errResponse(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, order)
+}
+
+func (server *Server) listStandingOrderExecutions(ctx *gin.Context) {
+	var uri StandingOrderUriParams
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	var req ListStandingOrdersParams
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	if _, valid := server.ownedStandingOrder(ctx, uri.ID); !valid {
+		return
+	}
+
+	executions, err := server.store.ListStandingOrderExecutions(ctx, db.ListStandingOrderExecutionsParams{
+		StandingOrderID: uri.ID,
+		Limit:           req.PageSize,
+		Offset:          req.PageSize * (req.PageID - 1),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, executions)
+}
+
+// ownedStandingOrder loads a standing order and checks it belongs to the authenticated user. When accountRoles are
+// given, members of the account the order pays from with one of those roles are let through as well.
+func (server *Server) ownedStandingOrder(ctx *gin.Context, id int64, accountRoles ...string) (db.StandingOrder, bool) {
+	order, err := server.store.GetStandingOrder(ctx, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			ctx.JSON(http.StatusNotFound, errResponse(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		}
+		return order, false
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	if order.Owner != authPayload.Username {
+		if len(accountRoles) > 0 {
+			return order, server.requireAccountRole(ctx, order.FromAccountID, accountRoles...)
+		}
+
+		err := errors.New("standing order does not belong to the authenticated user")
+		ctx.JSON(http.StatusForbidden, errResponse(err))
+		return order, false
+	}
+
+	return order, true
+}
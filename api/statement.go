@@ -0,0 +1,105 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/haniifac/simplebank/db/sqlc"
+	"github.com/haniifac/simplebank/statement"
+)
+
+type GetAccountStatementUriParams struct {
+	AccountID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type GetAccountStatementParams struct {
+	StartTime time.Time `form:"start_time" binding:"required"`
+	// EndTime defaults to now
+	EndTime time.Time `form:"end_time"`
+	// Format of the statement, JSON unless csv, ofx or mt940 is requested
+	Format string `form:"format" binding:"omitempty,oneof=json csv ofx mt940"`
+}
+
+func (server *Server) getAccountStatement(ctx *gin.Context) {
+	var uri GetAccountStatementUriParams
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	var req GetAccountStatementParams
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	if req.EndTime.IsZero() {
+		req.EndTime = time.Now()
+	}
+
+	if !req.EndTime.After(req.StartTime) {
+		err := errors.New("end_time must be after start_time")
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	if _, valid := server.ownedAccount(ctx, uri.AccountID); !valid {
+		return
+	}
+
+	arg := db.AccountStatementTxParams{
+		AccountID: uri.AccountID,
+		StartTime: req.StartTime,
+		EndTime:   req.EndTime,
+	}
+
+	if format, ok := statement.Lookup(req.Format); ok {
+		server.exportAccountStatement(ctx, arg, format)
+		return
+	}
+
+	result, err := server.store.AccountStatementTx(ctx, arg)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// exportAccountStatement streams the statement to the client while it is read from the database
+func (server *Server) exportAccountStatement(ctx *gin.Context, arg db.AccountStatementTxParams, format statement.Format) {
+	filename := fmt.Sprintf("statement-%d-%s.%s", arg.AccountID, arg.EndTime.UTC().Format("20060102"), format.Extension)
+	ctx.Header("Content-Type", format.ContentType)
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+	err := server.store.StreamAccountStatementTx(ctx, arg, format.NewWriter(ctx.Writer))
+	if err == nil {
+		return
+	}
+
+	// once the first bytes are out the status can no longer change, so the response is cut short instead
+	if ctx.Writer.Written() {
+		log.Printf("cannot export statement of account %d: %v", arg.AccountID, err)
+		ctx.Abort()
+		return
+	}
+
+	ctx.Header("Content-Type", "")
+	ctx.Header("Content-Disposition", "")
+	if errors.Is(err, sql.ErrNoRows) {
+		ctx.JSON(http.StatusNotFound, errResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusInternalServerError, errResponse(err))
+}
@@ -0,0 +1,201 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	mockdb "github.com/haniifac/simplebank/db/mock"
+	db "github.com/haniifac/simplebank/db/sqlc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetAccountStatementAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	other, _ := randomUser(t)
+	account := randomAccount(user.Username)
+
+	startTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	endTime := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	statement := db.AccountStatementTxResult{
+		Account:        account,
+		StartTime:      startTime,
+		EndTime:        endTime,
+		OpeningBalance: 100,
+		ClosingBalance: 70,
+		Lines: []db.StatementLine{
+			{EntryID: 1, Amount: -50, Balance: 50},
+			{EntryID: 2, Amount: 20, Balance: 70},
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		username      string
+		query         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			username: user.Username,
+			query:    "start_time=2024-01-01T00:00:00Z&end_time=2024-02-01T00:00:00Z",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				expectMember(store, account.ID, user.Username, db.MemberOwner)
+				store.EXPECT().
+					AccountStatementTx(gomock.Any(), gomock.Eq(db.AccountStatementTxParams{
+						AccountID: account.ID,
+						StartTime: startTime,
+						EndTime:   endTime,
+					})).
+					Times(1).
+					Return(statement, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.AccountStatementTxResult
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, statement.OpeningBalance, got.OpeningBalance)
+				require.Equal(t, statement.ClosingBalance, got.ClosingBalance)
+				require.Len(t, got.Lines, 2)
+			},
+		},
+		{
+			name:     "CSV",
+			username: user.Username,
+			query:    "start_time=2024-01-01T00:00:00Z&end_time=2024-02-01T00:00:00Z&format=csv",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				expectMember(store, account.ID, user.Username, db.MemberOwner)
+				store.EXPECT().AccountStatementTx(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().
+					StreamAccountStatementTx(gomock.Any(), gomock.Eq(db.AccountStatementTxParams{
+						AccountID: account.ID,
+						StartTime: startTime,
+						EndTime:   endTime,
+					}), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.AccountStatementTxParams, w db.StatementWriter) error {
+						require.NoError(t, w.WriteHeader(account, arg, statement.OpeningBalance))
+						for _, line := range statement.Lines {
+							require.NoError(t, w.WriteLine(line))
+						}
+						return w.WriteFooter(statement.ClosingBalance)
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, "text/csv; charset=utf-8", recorder.Header().Get("Content-Type"))
+				require.Contains(t, recorder.Header().Get("Content-Disposition"), "attachment")
+
+				lines := strings.Split(strings.TrimSpace(recorder.Body.String()), "\n")
+				require.Len(t, lines, len(statement.Lines)+1)
+			},
+		},
+		{
+			name:     "ExportError",
+			username: user.Username,
+			query:    "start_time=2024-01-01T00:00:00Z&format=mt940",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				expectMember(store, account.ID, user.Username, db.MemberOwner)
+				store.EXPECT().
+					StreamAccountStatementTx(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+				require.Contains(t, recorder.Header().Get("Content-Type"), "application/json")
+				require.Empty(t, recorder.Header().Get("Content-Disposition"))
+			},
+		},
+		{
+			name:     "InvalidFormat",
+			username: user.Username,
+			query:    "start_time=2024-01-01T00:00:00Z&format=pdf",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().StreamAccountStatementTx(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "MissingStartTime",
+			username: user.Username,
+			query:    "end_time=2024-02-01T00:00:00Z",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().AccountStatementTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "EndBeforeStart",
+			username: user.Username,
+			query:    "start_time=2024-02-01T00:00:00Z&end_time=2024-01-01T00:00:00Z",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().AccountStatementTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "NotOwner",
+			username: other.Username,
+			query:    "start_time=2024-01-01T00:00:00Z",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				expectNotMember(store, account.ID, other.Username)
+				store.EXPECT().AccountStatementTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:     "AccountNotFound",
+			username: user.Username,
+			query:    "start_time=2024-01-01T00:00:00Z",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(db.Account{}, sql.ErrNoRows)
+				store.EXPECT().AccountStatementTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/accounts/%d/statement?%s", account.ID, tc.query)
+			req := httptest.NewRequest(http.MethodGet, url, nil)
+			addAuthorization(t, req, server.tokenMaker, tc.username, "bearer", time.Minute)
+			server.router.ServeHTTP(recorder, req)
+
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
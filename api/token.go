@@ -3,18 +3,22 @@ package api
 import (
 	"database/sql"
 	"errors"
-	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	db "github.com/haniifac/simplebank/db/sqlc"
+	"github.com/haniifac/simplebank/token"
 )
 
 type RenewAccessTokenRequest struct {
 	RefreshToken string `json:"refresh_token" binding:"required"`
 }
 
+// RenewAccessTokenResponse carries the new refresh token, the one in the request cannot be used again
 type RenewAccessTokenResponse struct {
-	AccessToken string `json:"access_token"`
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
 }
 
 func (server *Server) renewAccessToken(ctx *gin.Context) {
@@ -30,8 +34,6 @@ func (server *Server) renewAccessToken(ctx *gin.Context) {
 		return
 	}
 
-	fmt.Println("RenewAccessToken payload:", payload.ID)
-
 	session, err := server.store.GetSession(ctx, payload.ID)
 	if err != nil {
 		switch err {
@@ -55,13 +57,74 @@ func (server *Server) renewAccessToken(ctx *gin.Context) {
 		return
 	}
 
-	newAccessToken, _, err := server.tokenMaker.CreateToken(payload.Username, server.config.AccessTokenDuration)
+	if session.RefreshToken != req.RefreshToken {
+		err = errors.New("refresh token does not match the session")
+		ctx.JSON(http.StatusUnauthorized, errResponse(err))
+		return
+	}
+
+	// the refresh token was replaced before, whoever presents it now may have stolen it
+	if session.UsedAt.Valid {
+		if err := server.store.BlockSessionFamily(ctx, session.FamilyID); err != nil {
+			ctx.JSON(http.StatusInternalServerError, errResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusUnauthorized, errResponse(db.ErrRefreshTokenReused))
+		return
+	}
+
+	if time.Now().After(session.ExpiresAt) {
+		err = errors.New("session is expired")
+		ctx.JSON(http.StatusUnauthorized, errResponse(err))
+		return
+	}
+
+	refreshToken, refreshPayload, err := server.tokenMaker.CreateToken(payload.Username, server.config.RefreshTokenDuration)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errResponse(err))
 		return
 	}
 
+	accessToken, _, err := server.tokenMaker.CreateSessionToken(payload.Username, refreshPayload.ID, server.config.AccessTokenDuration)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	// a used refresh token is rejected inside the transaction too, so two concurrent refreshes cannot both succeed
+	_, err = server.store.RotateSessionTx(ctx, db.RotateSessionTxParams{
+		Session:    session,
+		NewSession: server.sessionParams(ctx, refreshToken, refreshPayload),
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRefreshTokenReused) {
+			ctx.JSON(http.StatusUnauthorized, errResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
 	ctx.JSON(http.StatusOK, RenewAccessTokenResponse{
-		AccessToken: newAccessToken,
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
 	})
 }
+
+// sessionParams describes the session of a new refresh token, which starts its own family
+// unless the caller sets the family of the session it replaces
+func (server *Server) sessionParams(ctx *gin.Context, refreshToken string, refreshPayload *token.Payload) db.CreateSessionParams {
+	return db.CreateSessionParams{
+		ID:           refreshPayload.ID,
+		Username:     refreshPayload.Username,
+		RefreshToken: refreshToken,
+		UserAgent:    ctx.Request.UserAgent(),
+		IpAddress:    ctx.ClientIP(),
+		CreatedAt:    time.Now(),
+		ExpiresAt:    time.Now().Add(server.config.RefreshTokenDuration),
+		IsBlocked:    false,
+		FamilyID:     refreshPayload.ID,
+	}
+}
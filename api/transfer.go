@@ -1,20 +1,36 @@
 package api
 
 import (
+	"crypto/sha256"
 	"database/sql"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	db "github.com/haniifac/simplebank/db/sqlc"
 	"github.com/haniifac/simplebank/token"
+	"github.com/haniifac/simplebank/util"
 )
 
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// defaultIdempotencyKeyTTL is used when no idempotency key TTL is configured
+const defaultIdempotencyKeyTTL = 24 * time.Hour
+
 type TransferRequestParams struct {
-	FromAccountID int64  `json:"from_account_id" binding:"required,min=1"`
-	ToAccountID   int64  `json:"to_account_id" binding:"required,min=1"`
-	Amount        int64  `json:"amount" binding:"required,gt=0"`
-	Currency      string `json:"currency" binding:"required,currency"`
+	FromAccountID int64 `json:"from_account_id" binding:"required,min=1"`
+	// ToAccountID or ToAccountNumber identifies the destination, but not both
+	ToAccountID     int64  `json:"to_account_id" binding:"required_without=ToAccountNumber,excluded_with=ToAccountNumber,omitempty,min=1"`
+	ToAccountNumber string `json:"to_account_number" binding:"omitempty,account_number"`
+	Amount          int64  `json:"amount" binding:"required,gt=0"`
+	Currency        string `json:"currency" binding:"required,currency"`
+	// QuoteID locks the exchange rate for a transfer to an account in another currency
+	QuoteID string `json:"quote_id" binding:"omitempty,uuid"`
 }
 
 func (server *Server) createTransfer(ctx *gin.Context) {
@@ -24,16 +40,42 @@ func (server *Server) createTransfer(ctx *gin.Context) {
 		return
 	}
 
+	if !server.requireVerifiedEmail(ctx) {
+		return
+	}
+
+	if req.ToAccountNumber != "" && !server.resolveAccountNumber(ctx, &req) {
+		return
+	}
+
 	fromAccount, valid := server.validAccount(ctx, req.FromAccountID, req.Currency)
 	if !valid {
 		return
 	}
 
+	if !server.requireAccountRole(ctx, fromAccount.ID, spendingRoles...) {
+		return
+	}
+
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
 
-	if fromAccount.Owner != authPayload.Username {
-		err := fmt.Errorf("from account %d does not belong to the authenticated user %s", req.FromAccountID, authPayload.Username)
-		ctx.JSON(http.StatusForbidden, errResponse(err))
+	var idempotencyKey *db.IdempotencyKeyParams
+	if key := ctx.GetHeader(idempotencyKeyHeader); key != "" {
+		ttl := server.config.IdempotencyKeyTTL
+		if ttl <= 0 {
+			ttl = defaultIdempotencyKeyTTL
+		}
+
+		idempotencyKey = &db.IdempotencyKeyParams{
+			Username:    authPayload.Username,
+			Key:         key,
+			RequestHash: hashTransferRequest(req),
+			ExpiresAt:   time.Now().Add(ttl),
+		}
+	}
+
+	if req.QuoteID != "" {
+		server.createExchangeTransfer(ctx, req, idempotencyKey)
 		return
 	}
 
@@ -42,22 +84,99 @@ func (server *Server) createTransfer(ctx *gin.Context) {
 		return
 	}
 
-	arg := db.TransferTxParams{
-		FromAccountID: req.FromAccountID,
-		ToAccountID:   req.ToAccountID,
-		Amount:        req.Amount,
+	result, err := server.store.TransferTx(ctx, db.TransferTxParams{
+		FromAccountID:  req.FromAccountID,
+		ToAccountID:    req.ToAccountID,
+		Amount:         req.Amount,
+		IdempotencyKey: idempotencyKey,
+	})
+	if err != nil {
+		server.transferError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// createExchangeTransfer books a transfer between accounts in different currencies at the rate of the requested quote
+func (server *Server) createExchangeTransfer(ctx *gin.Context, req TransferRequestParams, idempotencyKey *db.IdempotencyKeyParams) {
+	quote, valid := server.ownedExchangeQuote(ctx, uuid.MustParse(req.QuoteID))
+	if !valid {
+		return
 	}
 
-	result, err := server.store.TransferTx(ctx, arg)
-	if err != nil {
+	if quote.FromCurrency != req.Currency {
+		err := fmt.Errorf("exchange quote %s converts from %s, not %s", quote.ID, quote.FromCurrency, req.Currency)
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
 
-		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+	_, valid = server.validAccount(ctx, req.ToAccountID, quote.ToCurrency)
+	if !valid {
+		return
+	}
+
+	result, err := server.store.ExchangeTransferTx(ctx, db.ExchangeTransferTxParams{
+		FromAccountID:  req.FromAccountID,
+		ToAccountID:    req.ToAccountID,
+		Amount:         req.Amount,
+		QuoteID:        quote.ID,
+		Username:       quote.Username,
+		IdempotencyKey: idempotencyKey,
+	})
+	if err != nil {
+		server.transferError(ctx, err)
 		return
 	}
 
 	ctx.JSON(http.StatusOK, result)
 }
 
+// transferError maps the errors of the transfer transactions to a response
+func (server *Server) transferError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, db.ErrIdempotencyKeyMismatch):
+		ctx.JSON(http.StatusUnprocessableEntity, errResponse(err))
+	case errors.Is(err, db.ErrIdempotencyKeyInUse):
+		ctx.JSON(http.StatusConflict, errResponse(err))
+	case errors.Is(err, db.ErrAccountNotActive):
+		ctx.JSON(http.StatusUnprocessableEntity, errCodeResponse(accountNotActiveCode, err))
+	case errors.Is(err, db.ErrInsufficientFunds):
+		ctx.JSON(http.StatusUnprocessableEntity, errResponse(err))
+	case errors.Is(err, db.ErrTransferLimitExceeded):
+		ctx.JSON(http.StatusUnprocessableEntity, errCodeResponse(transferLimitExceededCode, err))
+	case errors.Is(err, db.ErrQuoteNotFound):
+		ctx.JSON(http.StatusNotFound, errResponse(err))
+	case errors.Is(err, db.ErrQuoteExpired), errors.Is(err, db.ErrQuoteUsed), errors.Is(err, db.ErrQuoteMismatch),
+		errors.Is(err, db.ErrAmountTooSmall):
+		ctx.JSON(http.StatusUnprocessableEntity, errResponse(err))
+	default:
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+	}
+}
+
+// resolveAccountNumber looks up the destination account of a transfer by its account number
+// and replaces the number with the account id, so the rest of the request is handled the same way
+func (server *Server) resolveAccountNumber(ctx *gin.Context, req *TransferRequestParams) bool {
+	number := util.NormalizeAccountNumber(req.ToAccountNumber)
+
+	account, err := server.store.GetAccountByNumber(ctx, number)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err := fmt.Errorf("account %s not found", number)
+			ctx.JSON(http.StatusNotFound, errResponse(err))
+			return false
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return false
+	}
+
+	req.ToAccountID = account.ID
+	req.ToAccountNumber = ""
+	return true
+}
+
 func (server *Server) validAccount(ctx *gin.Context, accountID int64, currency string) (db.Account, bool) {
 	account, err := server.store.GetAccount(ctx, accountID)
 	if err != nil {
@@ -80,3 +199,176 @@ func (server *Server) validAccount(ctx *gin.Context, accountID int64, currency s
 
 	return account, true
 }
+
+type GetTransferParams struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// getTransfer returns a transfer when the authenticated user is a member of the sending or the receiving account
+func (server *Server) getTransfer(ctx *gin.Context) {
+	var req GetTransferParams
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	transfer, err := server.store.GetTransfer(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err := fmt.Errorf("transfer %d not found", req.ID)
+			ctx.JSON(http.StatusNotFound, errResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	for _, accountID := range []int64{transfer.FromAccountID, transfer.ToAccountID} {
+		_, err := server.store.GetAccountMember(ctx, db.GetAccountMemberParams{
+			AccountID: accountID,
+			Username:  authPayload.Username,
+		})
+		if err == nil {
+			ctx.JSON(http.StatusOK, transfer)
+			return
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusInternalServerError, errResponse(err))
+			return
+		}
+	}
+
+	err = fmt.Errorf("transfer %d does not belong to the authenticated user %s", req.ID, authPayload.Username)
+	ctx.JSON(http.StatusForbidden, errResponse(err))
+}
+
+type ListAccountTransfersUriParams struct {
+	AccountID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// ListAccountTransfersParams filters the transfers of an account. Results are returned newest first,
+// the next page starts before the id of the last transfer of the previous one.
+type ListAccountTransfersParams struct {
+	Direction string    `form:"direction" binding:"omitempty,oneof=in out"`
+	StartTime time.Time `form:"start_time"`
+	EndTime   time.Time `form:"end_time"`
+	MinAmount int64     `form:"min_amount" binding:"omitempty,gt=0"`
+	MaxAmount int64     `form:"max_amount" binding:"omitempty,gt=0"`
+	BeforeID  int64     `form:"before_id" binding:"omitempty,min=1"`
+	PageSize  int32     `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+func (server *Server) listAccountTransfers(ctx *gin.Context) {
+	var uri ListAccountTransfersUriParams
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	var req ListAccountTransfersParams
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	if !req.StartTime.IsZero() && !req.EndTime.IsZero() && !req.EndTime.After(req.StartTime) {
+		err := errors.New("end_time must be after start_time")
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	if req.MinAmount > 0 && req.MaxAmount > 0 && req.MaxAmount < req.MinAmount {
+		err := errors.New("max_amount must not be less than min_amount")
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	if _, valid := server.ownedAccount(ctx, uri.AccountID); !valid {
+		return
+	}
+
+	transfers, err := server.store.ListAccountTransfers(ctx, db.ListAccountTransfersParams{
+		AccountID: uri.AccountID,
+		Direction: sql.NullString{String: req.Direction, Valid: req.Direction != ""},
+		StartTime: sql.NullTime{Time: req.StartTime, Valid: !req.StartTime.IsZero()},
+		EndTime:   sql.NullTime{Time: req.EndTime, Valid: !req.EndTime.IsZero()},
+		MinAmount: sql.NullInt64{Int64: req.MinAmount, Valid: req.MinAmount > 0},
+		MaxAmount: sql.NullInt64{Int64: req.MaxAmount, Valid: req.MaxAmount > 0},
+		BeforeID:  sql.NullInt64{Int64: req.BeforeID, Valid: req.BeforeID > 0},
+		PageSize:  req.PageSize,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, transfers)
+}
+
+type ReverseTransferUriParams struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type ReverseTransferRequestParams struct {
+	// Amount to reverse in the currency of the sending account, omitted for a full reversal
+	Amount int64 `json:"amount" binding:"omitempty,gt=0"`
+}
+
+func (server *Server) reverseTransfer(ctx *gin.Context) {
+	var uri ReverseTransferUriParams
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	var req ReverseTransferRequestParams
+	if err := ctx.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	transfer, err := server.store.GetTransfer(ctx, uri.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err := fmt.Errorf("transfer %d not found", uri.ID)
+			ctx.JSON(http.StatusNotFound, errResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	// the money goes back out of the receiving account, so only those who may spend from it or an admin can reverse
+	if _, valid := server.managedAccount(ctx, transfer.ToAccountID, spendingRoles...); !valid {
+		return
+	}
+
+	result, err := server.store.ReverseTransferTx(ctx, db.ReverseTransferTxParams{
+		TransferID: uri.ID,
+		Amount:     req.Amount,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, db.ErrAccountNotActive):
+			ctx.JSON(http.StatusUnprocessableEntity, errCodeResponse(accountNotActiveCode, err))
+		case errors.Is(err, db.ErrTransferIsReversal), errors.Is(err, db.ErrReversalExceedsTotal),
+			errors.Is(err, db.ErrReversalTooSmall), errors.Is(err, db.ErrInsufficientFunds):
+			ctx.JSON(http.StatusUnprocessableEntity, errResponse(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// hashTransferRequest fingerprints a transfer request so a reused idempotency key can be matched against its original body
+func hashTransferRequest(req TransferRequestParams) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%d:%d:%d:%s:%s", req.FromAccountID, req.ToAccountID, req.Amount, req.Currency, req.QuoteID)))
+	return hex.EncodeToString(sum[:])
+}
@@ -3,6 +3,8 @@ package api
 import (
 	"database/sql"
 	"errors"
+	"fmt"
+	"log"
 	"net/http"
 	"time"
 
@@ -26,6 +28,7 @@ type userResponse struct {
 	Email             string    `json:"email"`
 	CreatedAt         time.Time `json:"created_at"`
 	PasswordUpdatedAt time.Time `json:"password_updated_at"`
+	IsEmailVerified   bool      `json:"is_email_verified"`
 }
 
 type loginUserRequest struct {
@@ -43,6 +46,11 @@ type GetUserRequest struct {
 	Username string `uri:"username" binding:"required"`
 }
 
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required,min=6"`
+}
+
 func castUserResponse(user db.User) userResponse {
 	return userResponse{
 		Username:          user.Username,
@@ -50,6 +58,7 @@ func castUserResponse(user db.User) userResponse {
 		Email:             user.Email,
 		CreatedAt:         user.CreatedAt,
 		PasswordUpdatedAt: user.PasswordUpdatedAt,
+		IsEmailVerified:   user.IsEmailVerified,
 	}
 }
 
@@ -87,6 +96,11 @@ func (server *Server) CreateUser(ctx *gin.Context) {
 		return
 	}
 
+	// the user can verify their email later, so a mail failure does not fail the signup
+	if err := server.sendVerificationEmail(ctx, user); err != nil {
+		log.Printf("cannot send verification email to %s: %v", user.Username, err)
+	}
+
 	ctx.JSON(http.StatusOK, user)
 }
 
@@ -142,34 +156,27 @@ func (server *Server) loginUser(ctx *gin.Context) {
 		return
 	}
 
-	accessToken, _, err := server.tokenMaker.CreateToken(
+	refreshToken, refreshPayload, err := server.tokenMaker.CreateToken(
 		user.Username,
-		server.config.AccessTokenDuration,
+		server.config.RefreshTokenDuration,
 	)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errResponse(err))
 		return
 	}
 
-	refreshToken, refreshPayload, err := server.tokenMaker.CreateToken(
+	// the access token stops working once the session of the refresh token is blocked
+	accessToken, _, err := server.tokenMaker.CreateSessionToken(
 		user.Username,
-		server.config.RefreshTokenDuration,
+		refreshPayload.ID,
+		server.config.AccessTokenDuration,
 	)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errResponse(err))
 		return
 	}
 
-	args := db.CreateSessionParams{
-		ID:           refreshPayload.ID,
-		Username:     user.Username,
-		RefreshToken: refreshToken,
-		UserAgent:    ctx.Request.UserAgent(),
-		IpAddress:    ctx.ClientIP(),
-		CreatedAt:    time.Now(),
-		ExpiresAt:    time.Now().Add(server.config.RefreshTokenDuration),
-		IsBlocked:    false,
-	}
+	args := server.sessionParams(ctx, refreshToken, refreshPayload)
 
 	_, err = server.store.CreateSession(ctx, args)
 
@@ -186,3 +193,89 @@ func (server *Server) loginUser(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, res)
 }
+
+// changePassword sets a new password after checking the current one. All sessions of the user are blocked
+// and access tokens issued before the change stop working, so every device has to log in again.
+func (server *Server) changePassword(ctx *gin.Context) {
+	var uri GetUserRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	var req ChangePasswordRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if authPayload.Username != uri.Username {
+		err := errors.New("user does not match authenticated user")
+		ctx.JSON(http.StatusForbidden, errResponse(err))
+		return
+	}
+
+	user, err := server.store.GetUser(ctx, uri.Username)
+	if err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			ctx.JSON(http.StatusNotFound, errResponse(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		}
+		return
+	}
+
+	if err := util.CheckPassword(req.CurrentPassword, user.HashedPassword); err != nil {
+		err := errors.New("current password is incorrect")
+		ctx.JSON(http.StatusUnauthorized, errResponse(err))
+		return
+	}
+
+	hashedPassword, err := util.HashPassword(req.NewPassword)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	user, err = server.store.ChangePasswordTx(ctx, db.ChangePasswordTxParams{
+		Username:       user.Username,
+		HashedPassword: hashedPassword,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, castUserResponse(user))
+}
+
+// isAdmin reports whether the user has the admin role
+func (server *Server) isAdmin(ctx *gin.Context, username string) (bool, error) {
+	user, err := server.store.GetUser(ctx, username)
+	if err != nil {
+		return false, err
+	}
+
+	return user.Role == util.AdminRole, nil
+}
+
+// requireAdmin writes the error response and returns false unless the authenticated user is an admin
+func (server *Server) requireAdmin(ctx *gin.Context, action string) bool {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	isAdmin, err := server.isAdmin(ctx, authPayload.Username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return false
+	}
+
+	if !isAdmin {
+		err := fmt.Errorf("only admins can %s", action)
+		ctx.JSON(http.StatusForbidden, errResponse(err))
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,122 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/haniifac/simplebank/db/sqlc"
+	"github.com/haniifac/simplebank/mail"
+	"github.com/haniifac/simplebank/token"
+	"github.com/haniifac/simplebank/util"
+)
+
+// defaultEmailVerificationDuration is used when no token duration is configured
+const defaultEmailVerificationDuration = 24 * time.Hour
+
+type VerifyEmailRequest struct {
+	Token string `form:"token" binding:"required"`
+}
+
+// sendVerificationEmail emails the user a token that proves they own their email address
+func (server *Server) sendVerificationEmail(ctx *gin.Context, user db.User) error {
+	verificationToken, err := util.NewSecret()
+	if err != nil {
+		return err
+	}
+
+	duration := server.config.EmailVerificationDuration
+	if duration <= 0 {
+		duration = defaultEmailVerificationDuration
+	}
+
+	_, err = server.store.CreateEmailVerification(ctx, db.CreateEmailVerificationParams{
+		Username:  user.Username,
+		Email:     user.Email,
+		TokenHash: util.HashSecret(verificationToken),
+		ExpiresAt: time.Now().Add(duration),
+	})
+	if err != nil {
+		return err
+	}
+
+	return server.mailer.Send(ctx, mail.Email{
+		To:      user.Email,
+		Subject: "Verify your email",
+		Body: fmt.Sprintf("Hi %s,\n\nUse this token to verify your email, it expires in %s:\n\n%s\n\n"+
+			"Send it to GET /users/verify-email?token=<token> to start making transfers.\n", user.Fullname, duration, verificationToken),
+	})
+}
+
+// verifyEmail marks the email of a user as verified with the token from their verification email
+func (server *Server) verifyEmail(ctx *gin.Context) {
+	var req VerifyEmailRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errResponse(err))
+		return
+	}
+
+	user, err := server.store.VerifyEmailTx(ctx, util.HashSecret(req.Token))
+	if err != nil {
+		if errors.Is(err, db.ErrVerificationTokenInvalid) {
+			ctx.JSON(http.StatusBadRequest, errResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, castUserResponse(user))
+}
+
+// resendVerificationEmail sends the authenticated user a new verification token, for when the first one expired
+// or the email never arrived. Earlier tokens stay valid until they expire.
+func (server *Server) resendVerificationEmail(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	user, err := server.store.GetUser(ctx, authPayload.Username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	if user.IsEmailVerified {
+		err := fmt.Errorf("email of user %s is already verified", user.Username)
+		ctx.JSON(http.StatusConflict, errResponse(err))
+		return
+	}
+
+	if err := server.sendVerificationEmail(ctx, user); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{})
+}
+
+// requireVerifiedEmail rejects the request when verified emails are required and the authenticated user
+// has not verified theirs yet
+func (server *Server) requireVerifiedEmail(ctx *gin.Context) bool {
+	if !server.config.RequireVerifiedEmail {
+		return true
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	user, err := server.store.GetUser(ctx, authPayload.Username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errResponse(err))
+		return false
+	}
+
+	if !user.IsEmailVerified {
+		err := fmt.Errorf("user %s has to verify their email first", user.Username)
+		ctx.JSON(http.StatusForbidden, errCodeResponse(emailNotVerifiedCode, err))
+		return false
+	}
+
+	return true
+}
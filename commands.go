@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	db "github.com/haniifac/simplebank/db/sqlc"
+	"github.com/haniifac/simplebank/exchange"
+	"github.com/haniifac/simplebank/reconcile"
+	"github.com/haniifac/simplebank/util"
+)
+
+// runCommand executes a one off command given on the command line instead of starting the server
+func runCommand(config util.Config, store db.Store, args []string) error {
+	switch args[0] {
+	case "load-exchange-rates":
+		if len(args) != 2 {
+			return fmt.Errorf("usage: load-exchange-rates <file.csv|file.xml>")
+		}
+		return loadExchangeRates(context.Background(), store, args[1])
+	case "set-bank-account":
+		if len(args) != 3 {
+			return fmt.Errorf("usage: set-bank-account <%s|%s> <account_id>", db.BankInterestExpense, db.BankFeeRevenue)
+		}
+		return setBankAccount(context.Background(), store, args[1], args[2])
+	case "reconcile":
+		return runReconcile(context.Background(), store, config.ReconcileBatchSize)
+	default:
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+}
+
+// loadExchangeRates stores the rates of a CSV or XML file together with their inverse and cross rates
+func loadExchangeRates(ctx context.Context, store db.Store, path string) error {
+	rates, err := exchange.LoadFile(path)
+	if err != nil {
+		return err
+	}
+
+	count := 0
+	for _, rate := range exchange.Complete(rates) {
+		if !util.IsSupportedCurrency(rate.From) || !util.IsSupportedCurrency(rate.To) {
+			continue
+		}
+
+		_, err := store.UpsertExchangeRate(ctx, db.UpsertExchangeRateParams{
+			FromCurrency: rate.From,
+			ToCurrency:   rate.To,
+			Rate:         exchange.FormatRate(rate.Rate),
+		})
+		if err != nil {
+			return fmt.Errorf("cannot store rate %s/%s: %w", rate.From, rate.To, err)
+		}
+		count++
+	}
+
+	log.Printf("loaded %d exchange rates from %s", count, path)
+	return nil
+}
+
+// setBankAccount makes an account the bank owned account for a purpose in the account's currency.
+// The account pays out money such as interest, so it needs funds or an overdraft limit.
+func setBankAccount(ctx context.Context, store db.Store, purpose string, id string) error {
+	switch purpose {
+	case db.BankInterestExpense, db.BankFeeRevenue:
+	default:
+		return fmt.Errorf("unknown bank account purpose %q", purpose)
+	}
+
+	accountID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid account id %q", id)
+	}
+
+	account, err := store.GetAccount(ctx, accountID)
+	if err != nil {
+		return fmt.Errorf("cannot get account %d: %w", accountID, err)
+	}
+
+	_, err = store.UpsertBankAccount(ctx, db.UpsertBankAccountParams{
+		Purpose:   purpose,
+		Currency:  account.Currency,
+		AccountID: account.ID,
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("account %d is the %s account for %s", account.ID, purpose, account.Currency)
+	return nil
+}
+
+// runReconcile checks the ledger, stores the report and prints it as JSON.
+// It fails when the ledger does not reconcile so that it can be used in scripts.
+func runReconcile(ctx context.Context, store db.Store, batchSize int32) error {
+	report, err := reconcile.NewReconciler(store, batchSize).Run(ctx)
+	if err != nil {
+		return err
+	}
+
+	if _, err := reconcile.Save(ctx, store, report); err != nil {
+		return fmt.Errorf("cannot save reconciliation report: %w", err)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+
+	if !report.OK {
+		return errors.New("ledger does not reconcile")
+	}
+	return nil
+}
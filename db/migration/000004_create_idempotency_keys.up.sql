@@ -0,0 +1,18 @@
+CREATE TABLE "idempotency_keys" (
+    "username" VARCHAR NOT NULL,
+    "key" VARCHAR NOT NULL,
+    "request_hash" VARCHAR NOT NULL,
+    "transfer_id" BIGINT NOT NULL,
+    "response" JSONB NOT NULL,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    "expires_at" TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY ("username", "key")
+);
+
+CREATE INDEX "idempotency_keys_expires_at_idx" ON "idempotency_keys" ("expires_at");
+
+COMMENT ON COLUMN "idempotency_keys"."request_hash" IS 'sha256 of the request body the key was first used with';
+COMMENT ON COLUMN "idempotency_keys"."response" IS 'transfer result returned on replay';
+
+ALTER TABLE "idempotency_keys" ADD CONSTRAINT "idempotency_keys_username_fk" FOREIGN KEY ("username") REFERENCES "users" ("username");
+ALTER TABLE "idempotency_keys" ADD CONSTRAINT "idempotency_keys_transfer_fk" FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
@@ -0,0 +1 @@
+ALTER TABLE "transfers" DROP COLUMN IF EXISTS "reversed_transfer_id";
\ No newline at end of file
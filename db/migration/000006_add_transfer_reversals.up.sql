@@ -0,0 +1,7 @@
+ALTER TABLE "transfers" ADD COLUMN "reversed_transfer_id" BIGINT;
+
+COMMENT ON COLUMN "transfers"."reversed_transfer_id" IS 'original transfer this transfer reverses';
+
+ALTER TABLE "transfers" ADD CONSTRAINT "transfers_reversed_transfer_fk" FOREIGN KEY ("reversed_transfer_id") REFERENCES "transfers" ("id");
+
+CREATE INDEX "transfers_reversed_transfer_idx" ON "transfers" ("reversed_transfer_id");
@@ -0,0 +1,25 @@
+CREATE TABLE "scheduled_transfers" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "owner" VARCHAR NOT NULL,
+    "from_account_id" BIGINT NOT NULL,
+    "to_account_id" BIGINT NOT NULL,
+    "amount" BIGINT NOT NULL,
+    "scheduled_at" TIMESTAMPTZ NOT NULL,
+    "status" VARCHAR NOT NULL DEFAULT 'pending',
+    "transfer_id" BIGINT,
+    "failure_reason" VARCHAR NOT NULL DEFAULT '',
+    "executed_at" TIMESTAMPTZ,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX "scheduled_transfers_owner_idx" ON "scheduled_transfers" ("owner");
+CREATE INDEX "scheduled_transfers_due_idx" ON "scheduled_transfers" ("scheduled_at") WHERE "status" = 'pending';
+
+COMMENT ON COLUMN "scheduled_transfers"."amount" IS 'must be positive';
+COMMENT ON COLUMN "scheduled_transfers"."status" IS 'pending, executed, failed or cancelled';
+
+ALTER TABLE "scheduled_transfers" ADD CONSTRAINT "scheduled_transfers_owner_fk" FOREIGN KEY ("owner") REFERENCES "users" ("username");
+ALTER TABLE "scheduled_transfers" ADD CONSTRAINT "scheduled_transfers_from_account_fk" FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+ALTER TABLE "scheduled_transfers" ADD CONSTRAINT "scheduled_transfers_to_account_fk" FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+ALTER TABLE "scheduled_transfers" ADD CONSTRAINT "scheduled_transfers_transfer_fk" FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+ALTER TABLE "scheduled_transfers" ADD CONSTRAINT "scheduled_transfers_check_amount" CHECK ("amount" > 0);
@@ -0,0 +1,47 @@
+CREATE TABLE "standing_orders" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "owner" VARCHAR NOT NULL,
+    "from_account_id" BIGINT NOT NULL,
+    "to_account_id" BIGINT NOT NULL,
+    "amount" BIGINT NOT NULL,
+    "frequency" VARCHAR NOT NULL,
+    "day_of_month" INT NOT NULL DEFAULT 0,
+    "insufficient_funds_policy" VARCHAR NOT NULL DEFAULT 'skip',
+    "next_run_at" TIMESTAMPTZ NOT NULL,
+    "retry_at" TIMESTAMPTZ,
+    "end_date" TIMESTAMPTZ,
+    "remaining_count" INT,
+    "status" VARCHAR NOT NULL DEFAULT 'active',
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "standing_order_executions" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "standing_order_id" BIGINT NOT NULL,
+    "scheduled_for" TIMESTAMPTZ NOT NULL,
+    "status" VARCHAR NOT NULL,
+    "transfer_id" BIGINT,
+    "failure_reason" VARCHAR NOT NULL DEFAULT '',
+    "executed_at" TIMESTAMPTZ NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX "standing_orders_owner_idx" ON "standing_orders" ("owner");
+CREATE INDEX "standing_orders_due_idx" ON "standing_orders" ((COALESCE("retry_at", "next_run_at"))) WHERE "status" = 'active';
+CREATE INDEX "standing_order_executions_order_idx" ON "standing_order_executions" ("standing_order_id");
+
+COMMENT ON COLUMN "standing_orders"."amount" IS 'must be positive';
+COMMENT ON COLUMN "standing_orders"."frequency" IS 'weekly, monthly or end_of_month';
+COMMENT ON COLUMN "standing_orders"."day_of_month" IS 'day a monthly order runs on, clamped to the month length';
+COMMENT ON COLUMN "standing_orders"."insufficient_funds_policy" IS 'skip or retry';
+COMMENT ON COLUMN "standing_orders"."next_run_at" IS 'next regular occurrence';
+COMMENT ON COLUMN "standing_orders"."retry_at" IS 'set while an occurrence that failed is waiting to be retried';
+COMMENT ON COLUMN "standing_orders"."remaining_count" IS 'occurrences left, null when unlimited';
+COMMENT ON COLUMN "standing_orders"."status" IS 'active, completed or cancelled';
+COMMENT ON COLUMN "standing_order_executions"."status" IS 'succeeded, retrying or skipped';
+
+ALTER TABLE "standing_orders" ADD CONSTRAINT "standing_orders_owner_fk" FOREIGN KEY ("owner") REFERENCES "users" ("username");
+ALTER TABLE "standing_orders" ADD CONSTRAINT "standing_orders_from_account_fk" FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+ALTER TABLE "standing_orders" ADD CONSTRAINT "standing_orders_to_account_fk" FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+ALTER TABLE "standing_orders" ADD CONSTRAINT "standing_orders_check_amount" CHECK ("amount" > 0);
+ALTER TABLE "standing_order_executions" ADD CONSTRAINT "standing_order_executions_order_fk" FOREIGN KEY ("standing_order_id") REFERENCES "standing_orders" ("id");
+ALTER TABLE "standing_order_executions" ADD CONSTRAINT "standing_order_executions_transfer_fk" FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
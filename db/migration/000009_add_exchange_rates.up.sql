@@ -0,0 +1,29 @@
+CREATE TABLE "exchange_rates" (
+    "from_currency" VARCHAR NOT NULL,
+    "to_currency" VARCHAR NOT NULL,
+    "rate" NUMERIC(20, 10) NOT NULL,
+    "updated_at" TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    PRIMARY KEY ("from_currency", "to_currency")
+);
+
+CREATE TABLE "exchange_quotes" (
+    "id" uuid NOT NULL PRIMARY KEY,
+    "username" VARCHAR NOT NULL,
+    "from_currency" VARCHAR NOT NULL,
+    "to_currency" VARCHAR NOT NULL,
+    "rate" NUMERIC(20, 10) NOT NULL,
+    "expires_at" TIMESTAMPTZ NOT NULL,
+    "used_at" TIMESTAMPTZ,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "transfers" ADD COLUMN "to_amount" BIGINT;
+ALTER TABLE "transfers" ADD COLUMN "exchange_rate" NUMERIC(20, 10);
+
+COMMENT ON COLUMN "exchange_rates"."rate" IS 'units of to_currency for one unit of from_currency';
+COMMENT ON COLUMN "exchange_quotes"."rate" IS 'rate locked until expires_at';
+COMMENT ON COLUMN "transfers"."to_amount" IS 'amount credited in the destination currency, null when both accounts share a currency';
+COMMENT ON COLUMN "transfers"."exchange_rate" IS 'rate applied to amount, null when both accounts share a currency';
+
+ALTER TABLE "exchange_rates" ADD CONSTRAINT "exchange_rates_check_rate" CHECK ("rate" > 0);
+ALTER TABLE "exchange_quotes" ADD CONSTRAINT "exchange_quotes_username_fk" FOREIGN KEY ("username") REFERENCES "users" ("username");
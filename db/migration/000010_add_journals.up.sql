@@ -0,0 +1,13 @@
+CREATE TABLE "journals" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "description" VARCHAR NOT NULL,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "entries" ADD COLUMN "journal_id" BIGINT;
+
+COMMENT ON COLUMN "entries"."journal_id" IS 'journal the entry was posted with, the entries of a journal sum to zero per currency';
+
+ALTER TABLE "entries" ADD CONSTRAINT "entries_journal_fk" FOREIGN KEY ("journal_id") REFERENCES "journals" ("id");
+
+CREATE INDEX "entries_journal_id_idx" ON "entries" ("journal_id");
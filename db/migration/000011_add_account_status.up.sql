@@ -0,0 +1,6 @@
+ALTER TABLE "accounts" ADD COLUMN "status" VARCHAR NOT NULL DEFAULT 'active';
+
+COMMENT ON COLUMN "accounts"."status" IS 'active, frozen or closed, only active accounts can send or receive money';
+
+ALTER TABLE "accounts" ADD CONSTRAINT "accounts_check_status" CHECK ("status" IN ('active', 'frozen', 'closed'));
+ALTER TABLE "accounts" ADD CONSTRAINT "accounts_check_closed_balance" CHECK ("status" <> 'closed' OR "balance" = 0);
@@ -0,0 +1,26 @@
+CREATE TABLE "holds" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "account_id" BIGINT NOT NULL,
+    "to_account_id" BIGINT NOT NULL,
+    "amount" BIGINT NOT NULL,
+    "description" VARCHAR NOT NULL DEFAULT '',
+    "status" VARCHAR NOT NULL DEFAULT 'active',
+    "expires_at" TIMESTAMPTZ NOT NULL,
+    "captured_amount" BIGINT,
+    "transfer_id" BIGINT,
+    "resolved_at" TIMESTAMPTZ,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX "holds_account_id_idx" ON "holds" ("account_id") WHERE "status" = 'active';
+CREATE INDEX "holds_expires_at_idx" ON "holds" ("expires_at") WHERE "status" = 'active';
+
+COMMENT ON COLUMN "holds"."amount" IS 'reserved on account_id until the hold is captured, released or expires';
+COMMENT ON COLUMN "holds"."status" IS 'active, captured, released or expired';
+COMMENT ON COLUMN "holds"."captured_amount" IS 'amount transferred to to_account_id, at most amount';
+
+ALTER TABLE "holds" ADD CONSTRAINT "holds_account_fk" FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+ALTER TABLE "holds" ADD CONSTRAINT "holds_to_account_fk" FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+ALTER TABLE "holds" ADD CONSTRAINT "holds_transfer_fk" FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+ALTER TABLE "holds" ADD CONSTRAINT "holds_check_amount" CHECK ("amount" > 0);
+ALTER TABLE "holds" ADD CONSTRAINT "holds_check_captured_amount" CHECK ("captured_amount" > 0 AND "captured_amount" <= "amount");
@@ -0,0 +1,3 @@
+ALTER TABLE "accounts" DROP CONSTRAINT IF EXISTS "accounts_check_balance";
+ALTER TABLE "accounts" ADD CONSTRAINT "accounts_check_balance" CHECK ("balance" >= 0);
+ALTER TABLE "accounts" DROP COLUMN IF EXISTS "overdraft_limit";
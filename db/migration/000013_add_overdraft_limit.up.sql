@@ -0,0 +1,7 @@
+ALTER TABLE "accounts" ADD COLUMN "overdraft_limit" BIGINT NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN "accounts"."overdraft_limit" IS 'how far below zero the balance may go, set by admins';
+
+ALTER TABLE "accounts" ADD CONSTRAINT "accounts_check_overdraft_limit" CHECK ("overdraft_limit" >= 0);
+ALTER TABLE "accounts" DROP CONSTRAINT IF EXISTS "accounts_check_balance";
+ALTER TABLE "accounts" ADD CONSTRAINT "accounts_check_balance" CHECK ("balance" >= -"overdraft_limit");
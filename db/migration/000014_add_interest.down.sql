@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS "interest_accruals";
+DROP TABLE IF EXISTS "interest_postings";
+DROP TABLE IF EXISTS "bank_accounts";
+
+ALTER TABLE "accounts" DROP CONSTRAINT IF EXISTS "unique_owner_currency_kind";
+ALTER TABLE "accounts" ADD CONSTRAINT "unique_owner_currency" UNIQUE ("owner", "currency");
+ALTER TABLE "accounts" DROP COLUMN IF EXISTS "kind";
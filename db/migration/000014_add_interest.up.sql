@@ -0,0 +1,59 @@
+ALTER TABLE "accounts" ADD COLUMN "kind" VARCHAR NOT NULL DEFAULT 'checking';
+
+COMMENT ON COLUMN "accounts"."kind" IS 'checking or savings, only savings accounts earn interest';
+
+ALTER TABLE "accounts" ADD CONSTRAINT "accounts_check_kind" CHECK ("kind" IN ('checking', 'savings'));
+ALTER TABLE "accounts" DROP CONSTRAINT IF EXISTS "unique_owner_currency";
+ALTER TABLE "accounts" ADD CONSTRAINT "unique_owner_currency_kind" UNIQUE ("owner", "currency", "kind");
+
+CREATE TABLE "bank_accounts" (
+    "purpose" VARCHAR NOT NULL,
+    "currency" VARCHAR NOT NULL,
+    "account_id" BIGINT NOT NULL,
+    PRIMARY KEY ("purpose", "currency")
+);
+
+COMMENT ON COLUMN "bank_accounts"."purpose" IS 'what the bank owned account books, such as interest_expense';
+
+ALTER TABLE "bank_accounts" ADD CONSTRAINT "bank_accounts_account_fk" FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+CREATE TABLE "interest_postings" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "account_id" BIGINT NOT NULL,
+    "period_end" DATE NOT NULL,
+    "accrued" NUMERIC(38, 18) NOT NULL,
+    "amount" BIGINT NOT NULL,
+    "carry" NUMERIC(38, 18) NOT NULL,
+    "journal_id" BIGINT,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT (now())
+);
+
+COMMENT ON COLUMN "interest_postings"."period_end" IS 'accruals dated before period_end are included';
+COMMENT ON COLUMN "interest_postings"."accrued" IS 'sum of the accruals of the period plus the carry of the previous posting, in minor units';
+COMMENT ON COLUMN "interest_postings"."amount" IS 'whole minor units credited to the account';
+COMMENT ON COLUMN "interest_postings"."carry" IS 'fraction of a minor unit left over for the next posting';
+
+ALTER TABLE "interest_postings" ADD CONSTRAINT "interest_postings_account_fk" FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+ALTER TABLE "interest_postings" ADD CONSTRAINT "interest_postings_journal_fk" FOREIGN KEY ("journal_id") REFERENCES "journals" ("id");
+ALTER TABLE "interest_postings" ADD CONSTRAINT "interest_postings_account_period_key" UNIQUE ("account_id", "period_end");
+
+CREATE TABLE "interest_accruals" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "account_id" BIGINT NOT NULL,
+    "accrual_date" DATE NOT NULL,
+    "balance" BIGINT NOT NULL,
+    "rate" NUMERIC(20, 10) NOT NULL,
+    "amount" NUMERIC(38, 18) NOT NULL,
+    "posting_id" BIGINT,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT (now())
+);
+
+COMMENT ON COLUMN "interest_accruals"."balance" IS 'end of day balance interest was accrued on';
+COMMENT ON COLUMN "interest_accruals"."rate" IS 'annual interest rate, 0.02 is 2%';
+COMMENT ON COLUMN "interest_accruals"."amount" IS 'interest of the day in minor units, including fractions';
+
+ALTER TABLE "interest_accruals" ADD CONSTRAINT "interest_accruals_account_fk" FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+ALTER TABLE "interest_accruals" ADD CONSTRAINT "interest_accruals_posting_fk" FOREIGN KEY ("posting_id") REFERENCES "interest_postings" ("id");
+ALTER TABLE "interest_accruals" ADD CONSTRAINT "interest_accruals_account_date_key" UNIQUE ("account_id", "accrual_date");
+
+CREATE INDEX "interest_accruals_unposted_idx" ON "interest_accruals" ("account_id", "accrual_date") WHERE "posting_id" IS NULL;
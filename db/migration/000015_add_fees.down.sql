@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "fee_charges";
+
+ALTER TABLE "accounts" DROP COLUMN IF EXISTS "fee_schedule_id";
+
+DROP TABLE IF EXISTS "fee_schedules";
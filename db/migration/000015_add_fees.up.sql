@@ -0,0 +1,48 @@
+CREATE TABLE "fee_schedules" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "name" VARCHAR NOT NULL,
+    "currency" VARCHAR NOT NULL,
+    "monthly_fee" BIGINT NOT NULL DEFAULT 0,
+    "transfer_fee" BIGINT NOT NULL DEFAULT 0,
+    "minimum_balance" BIGINT NOT NULL DEFAULT 0,
+    "below_minimum_fee" BIGINT NOT NULL DEFAULT 0,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT (now())
+);
+
+COMMENT ON COLUMN "fee_schedules"."monthly_fee" IS 'maintenance fee charged for every month';
+COMMENT ON COLUMN "fee_schedules"."transfer_fee" IS 'charged on every outgoing transfer';
+COMMENT ON COLUMN "fee_schedules"."below_minimum_fee" IS 'charged for a month ending with a balance below minimum_balance';
+
+ALTER TABLE "fee_schedules" ADD CONSTRAINT "fee_schedules_check_fees" CHECK (
+    "monthly_fee" >= 0 AND "transfer_fee" >= 0 AND "minimum_balance" >= 0 AND "below_minimum_fee" >= 0
+);
+
+ALTER TABLE "accounts" ADD COLUMN "fee_schedule_id" BIGINT;
+
+ALTER TABLE "accounts" ADD CONSTRAINT "accounts_fee_schedule_fk" FOREIGN KEY ("fee_schedule_id") REFERENCES "fee_schedules" ("id");
+
+CREATE TABLE "fee_charges" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "account_id" BIGINT NOT NULL,
+    "fee_schedule_id" BIGINT NOT NULL,
+    "kind" VARCHAR NOT NULL,
+    "amount" BIGINT NOT NULL,
+    "period_end" DATE,
+    "transfer_id" BIGINT,
+    "journal_id" BIGINT NOT NULL,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT (now())
+);
+
+COMMENT ON COLUMN "fee_charges"."kind" IS 'transfer, monthly or below_minimum';
+COMMENT ON COLUMN "fee_charges"."period_end" IS 'first day after the month a periodic fee was charged for';
+COMMENT ON COLUMN "fee_charges"."transfer_id" IS 'transfer a transfer fee was charged for';
+
+ALTER TABLE "fee_charges" ADD CONSTRAINT "fee_charges_account_fk" FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+ALTER TABLE "fee_charges" ADD CONSTRAINT "fee_charges_fee_schedule_fk" FOREIGN KEY ("fee_schedule_id") REFERENCES "fee_schedules" ("id");
+ALTER TABLE "fee_charges" ADD CONSTRAINT "fee_charges_transfer_fk" FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+ALTER TABLE "fee_charges" ADD CONSTRAINT "fee_charges_journal_fk" FOREIGN KEY ("journal_id") REFERENCES "journals" ("id");
+ALTER TABLE "fee_charges" ADD CONSTRAINT "fee_charges_check_kind" CHECK ("kind" IN ('transfer', 'monthly', 'below_minimum'));
+ALTER TABLE "fee_charges" ADD CONSTRAINT "fee_charges_check_amount" CHECK ("amount" > 0);
+ALTER TABLE "fee_charges" ADD CONSTRAINT "fee_charges_account_kind_period_key" UNIQUE ("account_id", "kind", "period_end");
+
+CREATE INDEX "fee_charges_account_id_idx" ON "fee_charges" ("account_id");
@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS "reconciliation_reports";
+
+-- entries of transfers booked before journals existed go back to having no journal
+UPDATE "entries" SET "journal_id" = NULL
+WHERE "journal_id" IN (SELECT "journal_id" FROM "journal_backfills");
+
+DELETE FROM "journals" WHERE "id" IN (SELECT "journal_id" FROM "journal_backfills");
+
+DROP TABLE IF EXISTS "journal_backfills";
+
+ALTER TABLE "journals" DROP COLUMN IF EXISTS "transfer_id";
@@ -0,0 +1,46 @@
+ALTER TABLE "journals" ADD COLUMN "transfer_id" BIGINT;
+
+COMMENT ON COLUMN "journals"."transfer_id" IS 'transfer the journal books, null for other journals';
+
+ALTER TABLE "journals" ADD CONSTRAINT "journals_transfer_fk" FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+ALTER TABLE "journals" ADD CONSTRAINT "journals_transfer_id_key" UNIQUE ("transfer_id");
+
+-- link the journals transfers were booked with since journals exist
+UPDATE "journals" SET "transfer_id" = substring("description" FROM 10)::bigint
+WHERE "description" ~ '^transfer [0-9]+$';
+
+CREATE TABLE "journal_backfills" (
+    "journal_id" BIGINT PRIMARY KEY
+);
+
+COMMENT ON TABLE "journal_backfills" IS 'journals the reconciliation migration created for transfers booked before journals existed, removed again when it is rolled back';
+
+ALTER TABLE "journal_backfills" ADD CONSTRAINT "journal_backfills_journal_fk" FOREIGN KEY ("journal_id") REFERENCES "journals" ("id");
+
+-- transfers booked before journals existed get a journal holding the entries created in the same transaction
+WITH "backfilled" AS (
+    INSERT INTO "journals" ("description", "transfer_id", "created_at")
+    SELECT 'transfer ' || t."id", t."id", t."created_at" FROM "transfers" t
+    WHERE NOT EXISTS (SELECT 1 FROM "journals" j WHERE j."transfer_id" = t."id")
+    RETURNING "id"
+)
+INSERT INTO "journal_backfills" ("journal_id") SELECT "id" FROM "backfilled";
+
+UPDATE "entries" e SET "journal_id" = j."id"
+FROM "journals" j
+JOIN "journal_backfills" b ON b."journal_id" = j."id"
+JOIN "transfers" t ON t."id" = j."transfer_id"
+WHERE e."journal_id" IS NULL
+    AND e."created_at" = t."created_at"
+    AND ((e."account_id" = t."from_account_id" AND e."amount" = -t."amount")
+        OR (e."account_id" = t."to_account_id" AND e."amount" = COALESCE(t."to_amount", t."amount")));
+
+CREATE TABLE "reconciliation_reports" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "started_at" TIMESTAMPTZ NOT NULL,
+    "finished_at" TIMESTAMPTZ NOT NULL,
+    "ok" BOOLEAN NOT NULL,
+    "report" JSONB NOT NULL
+);
+
+COMMENT ON COLUMN "reconciliation_reports"."ok" IS 'true when no mismatch or orphaned entry was found';
@@ -0,0 +1,13 @@
+CREATE TABLE "balance_snapshots" (
+    "account_id" BIGINT NOT NULL,
+    "taken_at" TIMESTAMPTZ NOT NULL,
+    "balance" BIGINT NOT NULL,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    PRIMARY KEY ("account_id", "taken_at")
+);
+
+COMMENT ON COLUMN "balance_snapshots"."balance" IS 'balance of the account from the entries created before taken_at';
+
+ALTER TABLE "balance_snapshots" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+CREATE INDEX "entries_account_id_created_at_idx" ON "entries" ("account_id", "created_at");
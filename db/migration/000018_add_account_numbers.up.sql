@@ -0,0 +1,15 @@
+ALTER TABLE "accounts" ADD COLUMN "number" VARCHAR(20);
+
+COMMENT ON COLUMN "accounts"."number" IS 'external IBAN style account number with mod 97 check digits';
+
+-- existing accounts get a random number, the check digits are computed like in util.NewAccountNumber
+WITH "generated" AS (
+    SELECT "id", lpad(floor(random() * 1e16)::bigint::text, 16, '0') AS "digits" FROM "accounts"
+)
+UPDATE "accounts" a
+SET "number" = 'SB' || lpad((98 - ((g."digits" || '281100')::numeric % 97))::text, 2, '0') || g."digits"
+FROM "generated" g
+WHERE g."id" = a."id";
+
+ALTER TABLE "accounts" ALTER COLUMN "number" SET NOT NULL;
+ALTER TABLE "accounts" ADD CONSTRAINT "accounts_number_key" UNIQUE ("number");
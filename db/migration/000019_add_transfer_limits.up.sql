@@ -0,0 +1,10 @@
+ALTER TABLE "accounts" ADD COLUMN "daily_limit" BIGINT;
+ALTER TABLE "accounts" ADD COLUMN "monthly_limit" BIGINT;
+
+COMMENT ON COLUMN "accounts"."daily_limit" IS 'most that may leave the account per UTC day, null uses the configured default';
+COMMENT ON COLUMN "accounts"."monthly_limit" IS 'most that may leave the account per UTC month, null uses the configured default';
+
+ALTER TABLE "accounts" ADD CONSTRAINT "accounts_check_daily_limit" CHECK ("daily_limit" >= 0);
+ALTER TABLE "accounts" ADD CONSTRAINT "accounts_check_monthly_limit" CHECK ("monthly_limit" >= 0);
+
+CREATE INDEX "transfers_from_account_created_at_idx" ON "transfers" ("from_account_id", "created_at");
@@ -0,0 +1,22 @@
+CREATE TABLE "account_members" (
+    "account_id" BIGINT NOT NULL,
+    "username" VARCHAR NOT NULL,
+    "role" VARCHAR NOT NULL,
+    "invited_by" VARCHAR,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    PRIMARY KEY ("account_id", "username")
+);
+
+COMMENT ON COLUMN "account_members"."role" IS 'owner, co_owner or viewer';
+COMMENT ON COLUMN "account_members"."invited_by" IS 'member that added the user, null for the owner that opened the account';
+
+ALTER TABLE "account_members" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id") ON DELETE CASCADE;
+ALTER TABLE "account_members" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+ALTER TABLE "account_members" ADD FOREIGN KEY ("invited_by") REFERENCES "users" ("username");
+ALTER TABLE "account_members" ADD CONSTRAINT "account_members_check_role" CHECK ("role" IN ('owner', 'co_owner', 'viewer'));
+
+CREATE INDEX "account_members_username_idx" ON "account_members" ("username");
+
+-- the user that opened an account stays its owner
+INSERT INTO "account_members" ("account_id", "username", "role", "created_at")
+SELECT "id", "owner", 'owner', "created_at" FROM "accounts";
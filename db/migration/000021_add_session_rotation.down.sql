@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS "sessions_family_id_idx";
+
+ALTER TABLE "sessions" DROP COLUMN IF EXISTS "used_at";
+ALTER TABLE "sessions" DROP COLUMN IF EXISTS "family_id";
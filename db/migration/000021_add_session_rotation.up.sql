@@ -0,0 +1,12 @@
+ALTER TABLE "sessions" ADD COLUMN "family_id" uuid;
+ALTER TABLE "sessions" ADD COLUMN "used_at" TIMESTAMPTZ;
+
+COMMENT ON COLUMN "sessions"."family_id" IS 'id of the login session the refresh token was rotated from';
+COMMENT ON COLUMN "sessions"."used_at" IS 'when the refresh token was exchanged for a new one, it cannot be used again';
+
+-- every existing session starts its own family
+UPDATE "sessions" SET "family_id" = "id";
+
+ALTER TABLE "sessions" ALTER COLUMN "family_id" SET NOT NULL;
+
+CREATE INDEX "sessions_family_id_idx" ON "sessions" ("family_id");
@@ -0,0 +1 @@
+CREATE INDEX "sessions_username_idx" ON "sessions" ("username");
@@ -0,0 +1,16 @@
+CREATE TABLE "password_reset_tokens" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "username" VARCHAR NOT NULL,
+    "token_hash" VARCHAR NOT NULL,
+    "expires_at" TIMESTAMPTZ NOT NULL,
+    "used_at" TIMESTAMPTZ,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT (now())
+);
+
+COMMENT ON COLUMN "password_reset_tokens"."token_hash" IS 'sha256 of the token sent by email, the token itself is never stored';
+COMMENT ON COLUMN "password_reset_tokens"."used_at" IS 'when the token was used or invalidated, it cannot be used again';
+
+ALTER TABLE "password_reset_tokens" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+CREATE UNIQUE INDEX "password_reset_tokens_token_hash_idx" ON "password_reset_tokens" ("token_hash");
+CREATE INDEX "password_reset_tokens_username_idx" ON "password_reset_tokens" ("username");
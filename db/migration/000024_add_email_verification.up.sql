@@ -0,0 +1,22 @@
+ALTER TABLE "users" ADD COLUMN "is_email_verified" BOOLEAN NOT NULL DEFAULT false;
+
+-- users that signed up before emails were verified keep using the bank as before
+UPDATE "users" SET "is_email_verified" = true;
+
+CREATE TABLE "email_verifications" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "username" VARCHAR NOT NULL,
+    "email" VARCHAR NOT NULL,
+    "token_hash" VARCHAR NOT NULL,
+    "expires_at" TIMESTAMPTZ NOT NULL,
+    "used_at" TIMESTAMPTZ,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT (now())
+);
+
+COMMENT ON COLUMN "email_verifications"."email" IS 'address the token was sent to, it only verifies the user while they still have it';
+COMMENT ON COLUMN "email_verifications"."token_hash" IS 'sha256 of the token sent by email, the token itself is never stored';
+
+ALTER TABLE "email_verifications" ADD FOREIGN KEY ("username") REFERENCES "users" ("username");
+
+CREATE UNIQUE INDEX "email_verifications_token_hash_idx" ON "email_verifications" ("token_hash");
+CREATE INDEX "email_verifications_username_idx" ON "email_verifications" ("username");
@@ -0,0 +1,7 @@
+ALTER TABLE "accounts" ADD COLUMN "fees_charged_until" DATE;
+
+COMMENT ON COLUMN "accounts"."fees_charged_until" IS 'first day after the last month whose periodic fees are all charged, null without a fee schedule';
+
+-- the month that ended last is charged again, fees that were already charged for it are skipped
+UPDATE "accounts" SET "fees_charged_until" = (date_trunc('month', now() AT TIME ZONE 'UTC') - INTERVAL '1 month')::date
+WHERE "fee_schedule_id" IS NOT NULL;
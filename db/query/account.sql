@@ -2,9 +2,11 @@
 INSERT INTO accounts (
     owner,
     balance,
-    currency
+    currency,
+    kind,
+    number
 ) VALUES (
-    $1, $2, $3
+    $1, $2, $3, $4, $5
 ) RETURNING *;
 
 -- name: GetAccount :one
@@ -25,4 +27,82 @@ UPDATE accounts SET balance = $2 WHERE id = $1 RETURNING *;
 DELETE FROM accounts WHERE id = $1;
 
 -- name: AddAccountBalance :one
-UPDATE accounts SET balance = balance + sqlc.arg(amount) WHERE id = $1 RETURNING *;
\ No newline at end of file
+UPDATE accounts SET balance = balance + sqlc.arg(amount) WHERE id = $1 RETURNING *;
+
+-- name: UpdateAccountStatus :one
+UPDATE accounts SET status = sqlc.arg(status)
+WHERE id = sqlc.arg(id) AND status = sqlc.arg(current_status)
+RETURNING *;
+
+-- name: CloseAccount :one
+-- only empty accounts that no hold, scheduled transfer or standing order still moves money in or out of can be closed
+UPDATE accounts SET status = 'closed'
+WHERE id = $1 AND balance = 0 AND status <> 'closed'
+    AND NOT EXISTS (
+        SELECT 1 FROM holds
+        WHERE (account_id = $1 OR to_account_id = $1) AND status = 'active' AND expires_at > now()
+    )
+    AND NOT EXISTS (
+        SELECT 1 FROM scheduled_transfers
+        WHERE (from_account_id = $1 OR to_account_id = $1) AND status = 'pending'
+    )
+    AND NOT EXISTS (
+        SELECT 1 FROM standing_orders
+        WHERE (from_account_id = $1 OR to_account_id = $1) AND status = 'active'
+    )
+RETURNING *;
+
+-- name: UpdateAccountOverdraftLimit :one
+UPDATE accounts SET overdraft_limit = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: ListInterestAccounts :many
+SELECT * FROM accounts
+WHERE kind = 'savings' AND status <> 'closed' AND id > $1
+ORDER BY id
+LIMIT $2;
+
+-- name: UpdateAccountFeeSchedule :one
+-- periodic fees of an account that gets its first fee schedule start with the current month
+UPDATE accounts SET fee_schedule_id = sqlc.narg(fee_schedule_id),
+    fees_charged_until = CASE
+        WHEN sqlc.narg(fee_schedule_id)::bigint IS NULL THEN NULL
+        WHEN fee_schedule_id IS NULL THEN date_trunc('month', now() AT TIME ZONE 'UTC')::date
+        ELSE fees_charged_until
+    END
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: ListFeeAccounts :many
+-- frozen accounts keep paying their fees, only closed accounts are skipped
+SELECT * FROM accounts
+WHERE fee_schedule_id IS NOT NULL AND status <> 'closed' AND fees_charged_until < sqlc.arg(period_end)::date AND id > sqlc.arg(after_id)
+ORDER BY id
+LIMIT sqlc.arg('limit');
+
+-- name: SettleFeePeriod :exec
+-- only moves on from the month before, so a month whose fees could not be charged is tried again
+UPDATE accounts SET fees_charged_until = sqlc.arg(period_end)::date
+WHERE id = sqlc.arg(id) AND fees_charged_until = (sqlc.arg(period_end)::date - INTERVAL '1 month')::date;
+
+-- name: GetAccountByNumber :one
+SELECT * FROM accounts WHERE number = $1 LIMIT 1;
+
+-- name: GetAccountCommitments :one
+-- counts what still moves money in or out of an account, see CloseAccount
+SELECT
+    (SELECT count(*) FROM holds h
+     WHERE (h.account_id = sqlc.arg(account_id) OR h.to_account_id = sqlc.arg(account_id))
+        AND h.status = 'active' AND h.expires_at > now()) AS active_holds,
+    (SELECT count(*) FROM scheduled_transfers s
+     WHERE (s.from_account_id = sqlc.arg(account_id) OR s.to_account_id = sqlc.arg(account_id))
+        AND s.status = 'pending') AS pending_scheduled_transfers,
+    (SELECT count(*) FROM standing_orders o
+     WHERE (o.from_account_id = sqlc.arg(account_id) OR o.to_account_id = sqlc.arg(account_id))
+        AND o.status = 'active') AS active_standing_orders;
+
+-- name: UpdateAccountTransferLimits :one
+UPDATE accounts SET daily_limit = $2, monthly_limit = $3
+WHERE id = $1
+RETURNING *;
\ No newline at end of file
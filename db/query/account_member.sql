@@ -0,0 +1,28 @@
+-- name: CreateAccountMember :one
+INSERT INTO account_members (
+    account_id,
+    username,
+    role,
+    invited_by
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetAccountMember :one
+SELECT * FROM account_members
+WHERE account_id = $1 AND username = $2 LIMIT 1;
+
+-- name: ListAccountMembers :many
+SELECT * FROM account_members
+WHERE account_id = $1
+ORDER BY created_at, username;
+
+-- name: ListMemberAccounts :many
+SELECT a.* FROM accounts a
+JOIN account_members m ON m.account_id = a.id
+WHERE m.username = $1
+ORDER BY a.id LIMIT $2 OFFSET $3;
+
+-- name: DeleteAccountMember :exec
+DELETE FROM account_members
+WHERE account_id = $1 AND username = $2 AND role <> 'owner';
\ No newline at end of file
@@ -0,0 +1,38 @@
+-- name: CreateBalanceSnapshot :one
+INSERT INTO balance_snapshots (
+    account_id,
+    taken_at,
+    balance
+)
+SELECT a.id, sqlc.arg(taken_at), (a.balance - COALESCE(SUM(e.amount), 0))::bigint
+FROM accounts a
+LEFT JOIN entries e ON e.account_id = a.id AND e.created_at >= sqlc.arg(taken_at)
+WHERE a.id = sqlc.arg(account_id)
+GROUP BY a.id, a.balance
+ON CONFLICT (account_id, taken_at) DO NOTHING
+RETURNING *;
+
+-- name: GetBalanceSnapshotAfter :one
+SELECT * FROM balance_snapshots
+WHERE account_id = sqlc.arg(account_id) AND taken_at > sqlc.arg(as_of)
+ORDER BY taken_at
+LIMIT 1;
+
+-- name: GetBalanceSnapshotBefore :one
+SELECT * FROM balance_snapshots
+WHERE account_id = sqlc.arg(account_id) AND taken_at <= sqlc.arg(as_of)
+ORDER BY taken_at DESC
+LIMIT 1;
+
+-- name: GetEntriesTotalBetween :one
+SELECT COALESCE(SUM(amount), 0)::bigint AS total
+FROM entries
+WHERE account_id = sqlc.arg(account_id)
+    AND created_at >= sqlc.arg(start_time)
+    AND created_at < sqlc.arg(end_time);
+
+-- name: ListSnapshotAccountIds :many
+SELECT id FROM accounts
+WHERE created_at < $1 AND id > $2
+ORDER BY id
+LIMIT $3;
\ No newline at end of file
@@ -0,0 +1,14 @@
+-- name: UpsertBankAccount :one
+INSERT INTO bank_accounts (
+    purpose,
+    currency,
+    account_id
+) VALUES (
+    $1, $2, $3
+) ON CONFLICT (purpose, currency) DO UPDATE SET account_id = EXCLUDED.account_id
+RETURNING *;
+
+-- name: GetBankAccount :one
+SELECT * FROM bank_accounts
+WHERE purpose = $1 AND currency = $2
+LIMIT 1;
\ No newline at end of file
@@ -0,0 +1,14 @@
+-- name: CreateEmailVerification :one
+INSERT INTO email_verifications (
+    username,
+    email,
+    token_hash,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: UseEmailVerification :one
+UPDATE email_verifications SET used_at = now()
+WHERE token_hash = $1 AND used_at IS NULL AND expires_at > now()
+RETURNING *;
\ No newline at end of file
@@ -1,13 +1,19 @@
 -- name: CreateEntry :one
 INSERT INTO entries (
     account_id,
-    amount
+    amount,
+    journal_id
 ) VALUES (
-    $1, $2
+    $1, $2, $3
 ) RETURNING *;
 
 -- name: GetEntry :one
 SELECT * FROM entries WHERE id = $1 LIMIT 1;
 
 -- name: ListEntries :many
-SELECT * FROM entries WHERE account_id = $1 ORDER BY id LIMIT $2 OFFSET $3;
\ No newline at end of file
+SELECT * FROM entries WHERE account_id = $1 ORDER BY id LIMIT $2 OFFSET $3;
+
+-- name: ListJournalEntries :many
+SELECT * FROM entries
+WHERE journal_id = $1
+ORDER BY id;
\ No newline at end of file
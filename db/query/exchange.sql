@@ -0,0 +1,43 @@
+-- name: UpsertExchangeRate :one
+INSERT INTO exchange_rates (
+    from_currency,
+    to_currency,
+    rate
+) VALUES (
+    $1, $2, $3
+) ON CONFLICT (from_currency, to_currency) DO UPDATE SET
+    rate = EXCLUDED.rate,
+    updated_at = now()
+RETURNING *;
+
+-- name: GetExchangeRate :one
+SELECT * FROM exchange_rates
+WHERE from_currency = $1 AND to_currency = $2
+LIMIT 1;
+
+-- name: ListExchangeRates :many
+SELECT * FROM exchange_rates
+ORDER BY from_currency, to_currency;
+
+-- name: CreateExchangeQuote :one
+INSERT INTO exchange_quotes (
+    id,
+    username,
+    from_currency,
+    to_currency,
+    rate,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: GetExchangeQuote :one
+SELECT * FROM exchange_quotes WHERE id = $1 LIMIT 1;
+
+-- name: GetExchangeQuoteForUpdate :one
+SELECT * FROM exchange_quotes WHERE id = $1 LIMIT 1 FOR UPDATE;
+
+-- name: UseExchangeQuote :one
+UPDATE exchange_quotes SET used_at = now()
+WHERE id = $1
+RETURNING *;
\ No newline at end of file
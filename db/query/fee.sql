@@ -0,0 +1,45 @@
+-- name: CreateFeeSchedule :one
+INSERT INTO fee_schedules (
+    name,
+    currency,
+    monthly_fee,
+    transfer_fee,
+    minimum_balance,
+    below_minimum_fee
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: GetFeeSchedule :one
+SELECT * FROM fee_schedules WHERE id = $1 LIMIT 1;
+
+-- name: ListFeeSchedules :many
+SELECT * FROM fee_schedules
+ORDER BY id
+LIMIT $1
+OFFSET $2;
+
+-- name: CreateFeeCharge :one
+INSERT INTO fee_charges (
+    account_id,
+    fee_schedule_id,
+    kind,
+    amount,
+    period_end,
+    transfer_id,
+    journal_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING *;
+
+-- name: GetPeriodicFeeCharge :one
+SELECT * FROM fee_charges
+WHERE account_id = $1 AND kind = $2 AND period_end = $3
+LIMIT 1;
+
+-- name: ListFeeCharges :many
+SELECT * FROM fee_charges
+WHERE account_id = $1
+ORDER BY id DESC
+LIMIT $2
+OFFSET $3;
\ No newline at end of file
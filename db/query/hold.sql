@@ -0,0 +1,46 @@
+-- name: CreateHold :one
+INSERT INTO holds (
+    account_id,
+    to_account_id,
+    amount,
+    description,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetHold :one
+SELECT * FROM holds WHERE id = $1 LIMIT 1;
+
+-- name: GetHoldForUpdate :one
+SELECT * FROM holds WHERE id = $1 LIMIT 1 FOR NO KEY UPDATE;
+
+-- name: ListActiveHolds :many
+SELECT * FROM holds
+WHERE account_id = $1 AND status = 'active' AND expires_at > now()
+ORDER BY id;
+
+-- name: GetHeldAmount :one
+SELECT COALESCE(SUM(amount), 0)::bigint AS held_amount FROM holds
+WHERE account_id = $1 AND status = 'active' AND expires_at > now();
+
+-- name: ResolveHold :one
+UPDATE holds SET
+    status = $2,
+    captured_amount = $3,
+    transfer_id = $4,
+    resolved_at = now()
+WHERE id = $1 AND status = 'active'
+RETURNING *;
+
+-- name: ExpireHolds :many
+UPDATE holds SET
+    status = 'expired',
+    resolved_at = now()
+WHERE status = 'active' AND expires_at <= now()
+RETURNING *;
+
+-- name: UpdateHoldTransfer :one
+UPDATE holds SET transfer_id = $2
+WHERE id = $1
+RETURNING *;
\ No newline at end of file
@@ -0,0 +1,50 @@
+-- name: CreateInterestAccrual :one
+INSERT INTO interest_accruals (
+    account_id,
+    accrual_date,
+    balance,
+    rate,
+    amount
+) VALUES (
+    $1, $2, $3, $4, $5
+) ON CONFLICT (account_id, accrual_date) DO NOTHING
+RETURNING *;
+
+-- name: GetLastInterestAccrual :one
+SELECT * FROM interest_accruals
+WHERE account_id = $1
+ORDER BY accrual_date DESC
+LIMIT 1;
+
+-- name: ListUnpostedInterestAccruals :many
+SELECT * FROM interest_accruals
+WHERE account_id = $1 AND posting_id IS NULL AND accrual_date < $2
+ORDER BY accrual_date
+FOR UPDATE;
+
+-- name: ListUnpostedInterestAccountIds :many
+SELECT DISTINCT account_id FROM interest_accruals
+WHERE posting_id IS NULL AND accrual_date < $1
+ORDER BY account_id;
+
+-- name: SetInterestAccrualsPosted :exec
+UPDATE interest_accruals SET posting_id = $1
+WHERE account_id = $2 AND posting_id IS NULL AND accrual_date < $3;
+
+-- name: CreateInterestPosting :one
+INSERT INTO interest_postings (
+    account_id,
+    period_end,
+    accrued,
+    amount,
+    carry,
+    journal_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: GetLastInterestPosting :one
+SELECT * FROM interest_postings
+WHERE account_id = $1
+ORDER BY period_end DESC
+LIMIT 1;
\ No newline at end of file
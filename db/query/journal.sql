@@ -0,0 +1,17 @@
+-- name: CreateJournal :one
+INSERT INTO journals (
+    description
+) VALUES (
+    $1
+) RETURNING *;
+
+-- name: CreateTransferJournal :one
+INSERT INTO journals (
+    description,
+    transfer_id
+) VALUES (
+    $1, $2
+) RETURNING *;
+
+-- name: GetJournal :one
+SELECT * FROM journals WHERE id = $1 LIMIT 1;
\ No newline at end of file
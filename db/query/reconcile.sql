@@ -0,0 +1,60 @@
+-- name: ListAccountEntryTotals :many
+SELECT a.id, a.balance, COALESCE(SUM(e.amount), 0)::bigint AS entries_total
+FROM accounts a
+LEFT JOIN entries e ON e.account_id = a.id
+WHERE a.id > $1
+GROUP BY a.id
+ORDER BY a.id
+LIMIT $2;
+
+-- name: ListTransferEntryTotals :many
+SELECT t.id, t.from_account_id, t.to_account_id, t.amount, t.to_amount, j.id AS journal_id,
+    (COUNT(e.id) FILTER (WHERE e.account_id = t.from_account_id AND e.amount < 0))::bigint AS debits,
+    (COALESCE(SUM(e.amount) FILTER (WHERE e.account_id = t.from_account_id AND e.amount < 0), 0))::bigint AS debit_total,
+    (COUNT(e.id) FILTER (WHERE e.account_id = t.to_account_id AND e.amount > 0))::bigint AS credits,
+    (COALESCE(SUM(e.amount) FILTER (WHERE e.account_id = t.to_account_id AND e.amount > 0), 0))::bigint AS credit_total,
+    COUNT(e.id)::bigint AS entries
+FROM transfers t
+LEFT JOIN journals j ON j.transfer_id = t.id
+LEFT JOIN entries e ON e.journal_id = j.id
+WHERE t.id > $1
+GROUP BY t.id, j.id
+ORDER BY t.id
+LIMIT $2;
+
+-- name: ListJournalCurrencyTotals :many
+-- a row per currency of each journal in the batch, exchange marks the journals of cross currency transfers
+SELECT j.id AS journal_id, a.currency, (t.to_amount IS NOT NULL)::boolean AS exchange,
+    COALESCE(SUM(e.amount), 0)::bigint AS total
+FROM (
+    SELECT id, transfer_id FROM journals
+    WHERE journals.id > $1
+    ORDER BY journals.id
+    LIMIT $2
+) j
+LEFT JOIN transfers t ON t.id = j.transfer_id
+LEFT JOIN entries e ON e.journal_id = j.id
+LEFT JOIN accounts a ON a.id = e.account_id
+GROUP BY j.id, a.currency, t.to_amount
+ORDER BY j.id, a.currency;
+
+-- name: ListOrphanedEntries :many
+SELECT * FROM entries
+WHERE journal_id IS NULL AND id > $1
+ORDER BY id
+LIMIT $2;
+
+-- name: CreateReconciliationReport :one
+INSERT INTO reconciliation_reports (
+    started_at,
+    finished_at,
+    ok,
+    report
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetLatestReconciliationReport :one
+SELECT * FROM reconciliation_reports
+ORDER BY id DESC
+LIMIT 1;
\ No newline at end of file
@@ -0,0 +1,39 @@
+-- name: CreateScheduledTransfer :one
+INSERT INTO scheduled_transfers (
+    owner,
+    from_account_id,
+    to_account_id,
+    amount,
+    scheduled_at
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetScheduledTransfer :one
+SELECT * FROM scheduled_transfers WHERE id = $1 LIMIT 1;
+
+-- name: ListScheduledTransfers :many
+SELECT * FROM scheduled_transfers
+WHERE owner = $1
+ORDER BY id LIMIT $2 OFFSET $3;
+
+-- name: GetDueScheduledTransferForUpdate :one
+SELECT * FROM scheduled_transfers
+WHERE status = 'pending' AND scheduled_at <= now()
+ORDER BY scheduled_at
+LIMIT 1
+FOR UPDATE SKIP LOCKED;
+
+-- name: CancelScheduledTransfer :one
+UPDATE scheduled_transfers SET status = 'cancelled'
+WHERE id = $1 AND status = 'pending'
+RETURNING *;
+
+-- name: UpdateScheduledTransferResult :one
+UPDATE scheduled_transfers SET
+    status = $2,
+    transfer_id = $3,
+    failure_reason = $4,
+    executed_at = now()
+WHERE id = $1 AND status = 'pending'
+RETURNING *;
\ No newline at end of file
@@ -7,10 +7,35 @@ INSERT INTO sessions (
     ip_address,
     created_at,
     expires_at,
-    is_blocked
+    is_blocked,
+    family_id
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
 ) RETURNING *;
 
 -- name: GetSession :one
-SELECT * FROM sessions WHERE id = $1 LIMIT 1;
\ No newline at end of file
+SELECT * FROM sessions WHERE id = $1 LIMIT 1;
+
+-- name: UseSession :one
+UPDATE sessions SET used_at = now()
+WHERE id = $1 AND used_at IS NULL
+RETURNING *;
+
+-- name: BlockSessionFamily :exec
+UPDATE sessions SET is_blocked = true
+WHERE family_id = $1;
+
+-- name: ListActiveSessions :many
+SELECT * FROM sessions
+WHERE username = $1 AND NOT is_blocked AND used_at IS NULL AND expires_at > now()
+ORDER BY created_at DESC;
+
+-- name: BlockUserSessions :exec
+UPDATE sessions SET is_blocked = true
+WHERE username = $1 AND NOT is_blocked;
+
+-- name: GetAuthSession :one
+SELECT s.id, s.username, s.is_blocked, u.password_updated_at
+FROM sessions s
+JOIN users u ON u.username = s.username
+WHERE s.id = $1 LIMIT 1;
\ No newline at end of file
@@ -0,0 +1,73 @@
+-- name: CreateStandingOrder :one
+INSERT INTO standing_orders (
+    owner,
+    from_account_id,
+    to_account_id,
+    amount,
+    frequency,
+    day_of_month,
+    insufficient_funds_policy,
+    next_run_at,
+    end_date,
+    remaining_count
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+) RETURNING *;
+
+-- name: GetStandingOrder :one
+SELECT * FROM standing_orders WHERE id = $1 LIMIT 1;
+
+-- name: ListStandingOrders :many
+SELECT * FROM standing_orders
+WHERE owner = $1
+ORDER BY id LIMIT $2 OFFSET $3;
+
+-- name: UpdateStandingOrder :one
+UPDATE standing_orders SET
+    amount = $2,
+    insufficient_funds_policy = $3,
+    end_date = $4
+WHERE id = $1 AND status = 'active'
+RETURNING *;
+
+-- name: CancelStandingOrder :one
+UPDATE standing_orders SET status = 'cancelled'
+WHERE id = $1 AND status = 'active'
+RETURNING *;
+
+-- name: GetDueStandingOrderForUpdate :one
+SELECT * FROM standing_orders
+WHERE status = 'active' AND COALESCE(retry_at, next_run_at) <= now()
+ORDER BY COALESCE(retry_at, next_run_at)
+LIMIT 1
+FOR UPDATE SKIP LOCKED;
+
+-- name: PostponeStandingOrder :exec
+-- leaves orders that were cancelled or finished in the meantime untouched
+UPDATE standing_orders SET retry_at = $2
+WHERE id = $1 AND status = 'active';
+
+-- name: UpdateStandingOrderSchedule :one
+UPDATE standing_orders SET
+    next_run_at = $2,
+    retry_at = $3,
+    remaining_count = $4,
+    status = $5
+WHERE id = $1
+RETURNING *;
+
+-- name: CreateStandingOrderExecution :one
+INSERT INTO standing_order_executions (
+    standing_order_id,
+    scheduled_for,
+    status,
+    transfer_id,
+    failure_reason
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: ListStandingOrderExecutions :many
+SELECT * FROM standing_order_executions
+WHERE standing_order_id = $1
+ORDER BY id DESC LIMIT $2 OFFSET $3;
\ No newline at end of file
@@ -0,0 +1,16 @@
+-- name: GetAccountBalanceAt :one
+SELECT (a.balance - COALESCE(SUM(e.amount), 0))::bigint AS balance
+FROM accounts a
+LEFT JOIN entries e ON e.account_id = a.id AND e.created_at >= sqlc.arg(at)
+WHERE a.id = sqlc.arg(account_id)
+GROUP BY a.id, a.balance;
+
+-- name: ListStatementEntries :many
+SELECT e.id, e.amount, e.created_at, e.journal_id, j.description,
+    (SUM(e.amount) OVER (ORDER BY e.created_at, e.id))::bigint AS running_total
+FROM entries e
+LEFT JOIN journals j ON j.id = e.journal_id
+WHERE e.account_id = sqlc.arg(account_id)
+    AND e.created_at >= sqlc.arg(start_time)
+    AND e.created_at < sqlc.arg(end_time)
+ORDER BY e.created_at, e.id;
\ No newline at end of file
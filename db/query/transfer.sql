@@ -2,16 +2,52 @@
 INSERT INTO transfers (
     from_account_id,
     to_account_id,
-    amount
+    amount,
+    reversed_transfer_id,
+    to_amount,
+    exchange_rate
 ) VALUES (
-    $1, $2, $3
+    $1, $2, $3, $4, $5, $6
 ) RETURNING *;
 
 -- name: GetTransfer :one
 SELECT * FROM transfers WHERE id = $1 LIMIT 1;
 
+-- name: GetTransferForUpdate :one
+SELECT * FROM transfers WHERE id = $1 LIMIT 1 FOR NO KEY UPDATE;
+
+-- name: GetReversedAmount :one
+-- reversed_amount is what was returned to the sender of the transfer, reversed_to_amount what was taken back from its receiver
+SELECT COALESCE(SUM(COALESCE(to_amount, amount)), 0)::bigint AS reversed_amount,
+    COALESCE(SUM(amount), 0)::bigint AS reversed_to_amount
+FROM transfers
+WHERE reversed_transfer_id = sqlc.arg(transfer_id)::bigint;
+
 -- name: ListTransfers :many
 SELECT * FROM transfers ORDER BY id LIMIT $1 OFFSET $2;
 
 -- name: ListTransfersByAccountId :many
-SELECT * FROM transfers WHERE from_account_id = $1 OR to_account_id = $2 ORDER BY id LIMIT $3 OFFSET $4;
\ No newline at end of file
+SELECT * FROM transfers WHERE from_account_id = $1 OR to_account_id = $2 ORDER BY id LIMIT $3 OFFSET $4;
+
+-- name: ListAccountTransfers :many
+SELECT * FROM transfers
+WHERE (from_account_id = sqlc.arg(account_id) OR to_account_id = sqlc.arg(account_id))
+    AND (sqlc.narg(direction)::text IS NULL
+        OR (sqlc.narg(direction)::text = 'out' AND from_account_id = sqlc.arg(account_id))
+        OR (sqlc.narg(direction)::text = 'in' AND to_account_id = sqlc.arg(account_id)))
+    AND (sqlc.narg(start_time)::timestamptz IS NULL OR created_at >= sqlc.narg(start_time)::timestamptz)
+    AND (sqlc.narg(end_time)::timestamptz IS NULL OR created_at < sqlc.narg(end_time)::timestamptz)
+    AND (sqlc.narg(min_amount)::bigint IS NULL OR amount >= sqlc.narg(min_amount)::bigint)
+    AND (sqlc.narg(max_amount)::bigint IS NULL OR amount <= sqlc.narg(max_amount)::bigint)
+    AND (sqlc.narg(before_id)::bigint IS NULL OR id < sqlc.narg(before_id)::bigint)
+ORDER BY id DESC
+LIMIT sqlc.arg(page_size);
+
+-- name: GetOutgoingTransferTotals :one
+SELECT
+    (COALESCE(SUM(amount) FILTER (WHERE created_at >= sqlc.arg(day_start)), 0))::bigint AS day_total,
+    (COALESCE(SUM(amount), 0))::bigint AS month_total
+FROM transfers
+WHERE from_account_id = sqlc.arg(account_id)
+    AND created_at >= sqlc.arg(month_start)
+    AND reversed_transfer_id IS NULL;
\ No newline at end of file
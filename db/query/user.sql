@@ -9,4 +9,17 @@ INSERT INTO users (
 ) RETURNING *;
 
 -- name: GetUser :one
-SELECT * FROM users WHERE username = $1 LIMIT 1;
\ No newline at end of file
+SELECT * FROM users WHERE username = $1 LIMIT 1;
+
+-- name: UpdateUserPassword :one
+UPDATE users SET hashed_password = $2, password_updated_at = now()
+WHERE username = $1
+RETURNING *;
+
+-- name: GetUserByEmail :one
+SELECT * FROM users WHERE email = $1 LIMIT 1;
+
+-- name: VerifyUserEmail :one
+UPDATE users SET is_email_verified = true
+WHERE username = $1 AND email = $2
+RETURNING *;
\ No newline at end of file
@@ -7,10 +7,12 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"time"
 )
 
 const addAccountBalance = `-- name: AddAccountBalance :one
-UPDATE accounts SET balance = balance + $2 WHERE id = $1 RETURNING id, owner, balance, currency, created_at
+UPDATE accounts SET balance = balance + $2 WHERE id = $1 RETURNING id, owner, balance, currency, created_at, status, overdraft_limit, kind, fee_schedule_id, number, daily_limit, monthly_limit, fees_charged_until
 `
 
 type AddAccountBalanceParams struct {
@@ -27,6 +29,54 @@ func (q *Queries) AddAccountBalance(ctx context.Context, arg AddAccountBalancePa
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.Status,
+		&i.OverdraftLimit,
+		&i.Kind,
+		&i.FeeScheduleID,
+		&i.Number,
+		&i.DailyLimit,
+		&i.MonthlyLimit,
+		&i.FeesChargedUntil,
+	)
+	return i, err
+}
+
+const closeAccount = `-- name: CloseAccount :one
+UPDATE accounts SET status = 'closed'
+WHERE id = $1 AND balance = 0 AND status <> 'closed'
+    AND NOT EXISTS (
+        SELECT 1 FROM holds
+        WHERE (account_id = $1 OR to_account_id = $1) AND status = 'active' AND expires_at > now()
+    )
+    AND NOT EXISTS (
+        SELECT 1 FROM scheduled_transfers
+        WHERE (from_account_id = $1 OR to_account_id = $1) AND status = 'pending'
+    )
+    AND NOT EXISTS (
+        SELECT 1 FROM standing_orders
+        WHERE (from_account_id = $1 OR to_account_id = $1) AND status = 'active'
+    )
+RETURNING id, owner, balance, currency, created_at, status, overdraft_limit, kind, fee_schedule_id, number, daily_limit, monthly_limit, fees_charged_until
+`
+
+// only empty accounts that no hold, scheduled transfer or standing order still moves money in or out of can be closed
+func (q *Queries) CloseAccount(ctx context.Context, id int64) (Account, error) {
+	row := q.db.QueryRowContext(ctx, closeAccount, id)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.Status,
+		&i.OverdraftLimit,
+		&i.Kind,
+		&i.FeeScheduleID,
+		&i.Number,
+		&i.DailyLimit,
+		&i.MonthlyLimit,
+		&i.FeesChargedUntil,
 	)
 	return i, err
 }
@@ -35,20 +85,30 @@ const createAccount = `-- name: CreateAccount :one
 INSERT INTO accounts (
     owner,
     balance,
-    currency
+    currency,
+    kind,
+    number
 ) VALUES (
-    $1, $2, $3
-) RETURNING id, owner, balance, currency, created_at
+    $1, $2, $3, $4, $5
+) RETURNING id, owner, balance, currency, created_at, status, overdraft_limit, kind, fee_schedule_id, number, daily_limit, monthly_limit, fees_charged_until
 `
 
 type CreateAccountParams struct {
 	Owner    string `json:"owner"`
 	Balance  int64  `json:"balance"`
 	Currency string `json:"currency"`
+	Kind     string `json:"kind"`
+	Number   string `json:"number"`
 }
 
 func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error) {
-	row := q.db.QueryRowContext(ctx, createAccount, arg.Owner, arg.Balance, arg.Currency)
+	row := q.db.QueryRowContext(ctx, createAccount,
+		arg.Owner,
+		arg.Balance,
+		arg.Currency,
+		arg.Kind,
+		arg.Number,
+	)
 	var i Account
 	err := row.Scan(
 		&i.ID,
@@ -56,6 +116,14 @@ func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (A
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.Status,
+		&i.OverdraftLimit,
+		&i.Kind,
+		&i.FeeScheduleID,
+		&i.Number,
+		&i.DailyLimit,
+		&i.MonthlyLimit,
+		&i.FeesChargedUntil,
 	)
 	return i, err
 }
@@ -70,7 +138,7 @@ func (q *Queries) DeleteAccount(ctx context.Context, id int64) error {
 }
 
 const getAccount = `-- name: GetAccount :one
-SELECT id, owner, balance, currency, created_at FROM accounts WHERE id = $1 LIMIT 1
+SELECT id, owner, balance, currency, created_at, status, overdraft_limit, kind, fee_schedule_id, number, daily_limit, monthly_limit, fees_charged_until FROM accounts WHERE id = $1 LIMIT 1
 `
 
 func (q *Queries) GetAccount(ctx context.Context, id int64) (Account, error) {
@@ -82,12 +150,72 @@ func (q *Queries) GetAccount(ctx context.Context, id int64) (Account, error) {
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.Status,
+		&i.OverdraftLimit,
+		&i.Kind,
+		&i.FeeScheduleID,
+		&i.Number,
+		&i.DailyLimit,
+		&i.MonthlyLimit,
+		&i.FeesChargedUntil,
 	)
 	return i, err
 }
 
+const getAccountByNumber = `-- name: GetAccountByNumber :one
+SELECT id, owner, balance, currency, created_at, status, overdraft_limit, kind, fee_schedule_id, number, daily_limit, monthly_limit, fees_charged_until FROM accounts WHERE number = $1 LIMIT 1
+`
+
+func (q *Queries) GetAccountByNumber(ctx context.Context, number string) (Account, error) {
+	row := q.db.QueryRowContext(ctx, getAccountByNumber, number)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.Status,
+		&i.OverdraftLimit,
+		&i.Kind,
+		&i.FeeScheduleID,
+		&i.Number,
+		&i.DailyLimit,
+		&i.MonthlyLimit,
+		&i.FeesChargedUntil,
+	)
+	return i, err
+}
+
+const getAccountCommitments = `-- name: GetAccountCommitments :one
+SELECT
+    (SELECT count(*) FROM holds h
+     WHERE (h.account_id = $1 OR h.to_account_id = $1)
+        AND h.status = 'active' AND h.expires_at > now()) AS active_holds,
+    (SELECT count(*) FROM scheduled_transfers s
+     WHERE (s.from_account_id = $1 OR s.to_account_id = $1)
+        AND s.status = 'pending') AS pending_scheduled_transfers,
+    (SELECT count(*) FROM standing_orders o
+     WHERE (o.from_account_id = $1 OR o.to_account_id = $1)
+        AND o.status = 'active') AS active_standing_orders
+`
+
+type GetAccountCommitmentsRow struct {
+	ActiveHolds               int64 `json:"active_holds"`
+	PendingScheduledTransfers int64 `json:"pending_scheduled_transfers"`
+	ActiveStandingOrders      int64 `json:"active_standing_orders"`
+}
+
+// counts what still moves money in or out of an account, see CloseAccount
+func (q *Queries) GetAccountCommitments(ctx context.Context, accountID int64) (GetAccountCommitmentsRow, error) {
+	row := q.db.QueryRowContext(ctx, getAccountCommitments, accountID)
+	var i GetAccountCommitmentsRow
+	err := row.Scan(&i.ActiveHolds, &i.PendingScheduledTransfers, &i.ActiveStandingOrders)
+	return i, err
+}
+
 const getAccountForUpdate = `-- name: GetAccountForUpdate :one
-SELECT id, owner, balance, currency, created_at FROM accounts WHERE id = $1 LIMIT 1 FOR NO KEY UPDATE
+SELECT id, owner, balance, currency, created_at, status, overdraft_limit, kind, fee_schedule_id, number, daily_limit, monthly_limit, fees_charged_until FROM accounts WHERE id = $1 LIMIT 1 FOR NO KEY UPDATE
 `
 
 func (q *Queries) GetAccountForUpdate(ctx context.Context, id int64) (Account, error) {
@@ -99,12 +227,20 @@ func (q *Queries) GetAccountForUpdate(ctx context.Context, id int64) (Account, e
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.Status,
+		&i.OverdraftLimit,
+		&i.Kind,
+		&i.FeeScheduleID,
+		&i.Number,
+		&i.DailyLimit,
+		&i.MonthlyLimit,
+		&i.FeesChargedUntil,
 	)
 	return i, err
 }
 
 const listAccounts = `-- name: ListAccounts :many
-SELECT id, owner, balance, currency, created_at FROM accounts
+SELECT id, owner, balance, currency, created_at, status, overdraft_limit, kind, fee_schedule_id, number, daily_limit, monthly_limit, fees_charged_until FROM accounts
 WHERE owner = $1
 ORDER BY id LIMIT $2 OFFSET $3
 `
@@ -130,6 +266,65 @@ func (q *Queries) ListAccounts(ctx context.Context, arg ListAccountsParams) ([]A
 			&i.Balance,
 			&i.Currency,
 			&i.CreatedAt,
+			&i.Status,
+			&i.OverdraftLimit,
+			&i.Kind,
+			&i.FeeScheduleID,
+			&i.Number,
+			&i.DailyLimit,
+			&i.MonthlyLimit,
+			&i.FeesChargedUntil,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listFeeAccounts = `-- name: ListFeeAccounts :many
+SELECT id, owner, balance, currency, created_at, status, overdraft_limit, kind, fee_schedule_id, number, daily_limit, monthly_limit, fees_charged_until FROM accounts
+WHERE fee_schedule_id IS NOT NULL AND status <> 'closed' AND fees_charged_until < $1::date AND id > $2
+ORDER BY id
+LIMIT $3
+`
+
+type ListFeeAccountsParams struct {
+	PeriodEnd time.Time `json:"period_end"`
+	AfterID   int64     `json:"after_id"`
+	Limit     int32     `json:"limit"`
+}
+
+// frozen accounts keep paying their fees, only closed accounts are skipped
+func (q *Queries) ListFeeAccounts(ctx context.Context, arg ListFeeAccountsParams) ([]Account, error) {
+	rows, err := q.db.QueryContext(ctx, listFeeAccounts, arg.PeriodEnd, arg.AfterID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Account{}
+	for rows.Next() {
+		var i Account
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.Balance,
+			&i.Currency,
+			&i.CreatedAt,
+			&i.Status,
+			&i.OverdraftLimit,
+			&i.Kind,
+			&i.FeeScheduleID,
+			&i.Number,
+			&i.DailyLimit,
+			&i.MonthlyLimit,
+			&i.FeesChargedUntil,
 		); err != nil {
 			return nil, err
 		}
@@ -144,8 +339,73 @@ func (q *Queries) ListAccounts(ctx context.Context, arg ListAccountsParams) ([]A
 	return items, nil
 }
 
+const listInterestAccounts = `-- name: ListInterestAccounts :many
+SELECT id, owner, balance, currency, created_at, status, overdraft_limit, kind, fee_schedule_id, number, daily_limit, monthly_limit, fees_charged_until FROM accounts
+WHERE kind = 'savings' AND status <> 'closed' AND id > $1
+ORDER BY id
+LIMIT $2
+`
+
+type ListInterestAccountsParams struct {
+	AfterID int64 `json:"after_id"`
+	Limit   int32 `json:"limit"`
+}
+
+func (q *Queries) ListInterestAccounts(ctx context.Context, arg ListInterestAccountsParams) ([]Account, error) {
+	rows, err := q.db.QueryContext(ctx, listInterestAccounts, arg.AfterID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Account{}
+	for rows.Next() {
+		var i Account
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.Balance,
+			&i.Currency,
+			&i.CreatedAt,
+			&i.Status,
+			&i.OverdraftLimit,
+			&i.Kind,
+			&i.FeeScheduleID,
+			&i.Number,
+			&i.DailyLimit,
+			&i.MonthlyLimit,
+			&i.FeesChargedUntil,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const settleFeePeriod = `-- name: SettleFeePeriod :exec
+UPDATE accounts SET fees_charged_until = $1::date
+WHERE id = $2 AND fees_charged_until = ($1::date - INTERVAL '1 month')::date
+`
+
+type SettleFeePeriodParams struct {
+	PeriodEnd time.Time `json:"period_end"`
+	ID        int64     `json:"id"`
+}
+
+// only moves on from the month before, so a month whose fees could not be charged is tried again
+func (q *Queries) SettleFeePeriod(ctx context.Context, arg SettleFeePeriodParams) error {
+	_, err := q.db.ExecContext(ctx, settleFeePeriod, arg.PeriodEnd, arg.ID)
+	return err
+}
+
 const updateAccount = `-- name: UpdateAccount :one
-UPDATE accounts SET balance = $2 WHERE id = $1 RETURNING id, owner, balance, currency, created_at
+UPDATE accounts SET balance = $2 WHERE id = $1 RETURNING id, owner, balance, currency, created_at, status, overdraft_limit, kind, fee_schedule_id, number, daily_limit, monthly_limit, fees_charged_until
 `
 
 type UpdateAccountParams struct {
@@ -162,6 +422,150 @@ func (q *Queries) UpdateAccount(ctx context.Context, arg UpdateAccountParams) (A
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.Status,
+		&i.OverdraftLimit,
+		&i.Kind,
+		&i.FeeScheduleID,
+		&i.Number,
+		&i.DailyLimit,
+		&i.MonthlyLimit,
+		&i.FeesChargedUntil,
+	)
+	return i, err
+}
+
+const updateAccountFeeSchedule = `-- name: UpdateAccountFeeSchedule :one
+UPDATE accounts SET fee_schedule_id = $1,
+    fees_charged_until = CASE
+        WHEN $1::bigint IS NULL THEN NULL
+        WHEN fee_schedule_id IS NULL THEN date_trunc('month', now() AT TIME ZONE 'UTC')::date
+        ELSE fees_charged_until
+    END
+WHERE id = $2
+RETURNING id, owner, balance, currency, created_at, status, overdraft_limit, kind, fee_schedule_id, number, daily_limit, monthly_limit, fees_charged_until
+`
+
+type UpdateAccountFeeScheduleParams struct {
+	FeeScheduleID sql.NullInt64 `json:"fee_schedule_id"`
+	ID            int64         `json:"id"`
+}
+
+// periodic fees of an account that gets its first fee schedule start with the current month
+func (q *Queries) UpdateAccountFeeSchedule(ctx context.Context, arg UpdateAccountFeeScheduleParams) (Account, error) {
+	row := q.db.QueryRowContext(ctx, updateAccountFeeSchedule, arg.FeeScheduleID, arg.ID)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.Status,
+		&i.OverdraftLimit,
+		&i.Kind,
+		&i.FeeScheduleID,
+		&i.Number,
+		&i.DailyLimit,
+		&i.MonthlyLimit,
+		&i.FeesChargedUntil,
+	)
+	return i, err
+}
+
+const updateAccountOverdraftLimit = `-- name: UpdateAccountOverdraftLimit :one
+UPDATE accounts SET overdraft_limit = $2
+WHERE id = $1
+RETURNING id, owner, balance, currency, created_at, status, overdraft_limit, kind, fee_schedule_id, number, daily_limit, monthly_limit, fees_charged_until
+`
+
+type UpdateAccountOverdraftLimitParams struct {
+	ID             int64 `json:"id"`
+	OverdraftLimit int64 `json:"overdraft_limit"`
+}
+
+func (q *Queries) UpdateAccountOverdraftLimit(ctx context.Context, arg UpdateAccountOverdraftLimitParams) (Account, error) {
+	row := q.db.QueryRowContext(ctx, updateAccountOverdraftLimit, arg.ID, arg.OverdraftLimit)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.Status,
+		&i.OverdraftLimit,
+		&i.Kind,
+		&i.FeeScheduleID,
+		&i.Number,
+		&i.DailyLimit,
+		&i.MonthlyLimit,
+		&i.FeesChargedUntil,
+	)
+	return i, err
+}
+
+const updateAccountStatus = `-- name: UpdateAccountStatus :one
+UPDATE accounts SET status = $1
+WHERE id = $2 AND status = $3
+RETURNING id, owner, balance, currency, created_at, status, overdraft_limit, kind, fee_schedule_id, number, daily_limit, monthly_limit, fees_charged_until
+`
+
+type UpdateAccountStatusParams struct {
+	Status        string `json:"status"`
+	ID            int64  `json:"id"`
+	CurrentStatus string `json:"current_status"`
+}
+
+func (q *Queries) UpdateAccountStatus(ctx context.Context, arg UpdateAccountStatusParams) (Account, error) {
+	row := q.db.QueryRowContext(ctx, updateAccountStatus, arg.Status, arg.ID, arg.CurrentStatus)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.Status,
+		&i.OverdraftLimit,
+		&i.Kind,
+		&i.FeeScheduleID,
+		&i.Number,
+		&i.DailyLimit,
+		&i.MonthlyLimit,
+		&i.FeesChargedUntil,
+	)
+	return i, err
+}
+
+const updateAccountTransferLimits = `-- name: UpdateAccountTransferLimits :one
+UPDATE accounts SET daily_limit = $2, monthly_limit = $3
+WHERE id = $1
+RETURNING id, owner, balance, currency, created_at, status, overdraft_limit, kind, fee_schedule_id, number, daily_limit, monthly_limit, fees_charged_until
+`
+
+type UpdateAccountTransferLimitsParams struct {
+	ID           int64         `json:"id"`
+	DailyLimit   sql.NullInt64 `json:"daily_limit"`
+	MonthlyLimit sql.NullInt64 `json:"monthly_limit"`
+}
+
+func (q *Queries) UpdateAccountTransferLimits(ctx context.Context, arg UpdateAccountTransferLimitsParams) (Account, error) {
+	row := q.db.QueryRowContext(ctx, updateAccountTransferLimits, arg.ID, arg.DailyLimit, arg.MonthlyLimit)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.Status,
+		&i.OverdraftLimit,
+		&i.Kind,
+		&i.FeeScheduleID,
+		&i.Number,
+		&i.DailyLimit,
+		&i.MonthlyLimit,
+		&i.FeesChargedUntil,
 	)
 	return i, err
 }
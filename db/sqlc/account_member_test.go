@@ -0,0 +1,86 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/haniifac/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+// createMemberAccount opens a funded account through CreateAccountTx, so its owner is a member that may spend from it
+func createMemberAccount(t *testing.T, currency string) Account {
+	user := createRandomUser(t)
+
+	account, err := NewStore(testDB).CreateAccountTx(context.Background(), CreateAccountParams{
+		Owner:    user.Username,
+		Balance:  util.RandomMoney(),
+		Currency: currency,
+		Kind:     AccountChecking,
+		Number:   util.RandomAccountNumber(),
+	})
+	require.NoError(t, err)
+
+	return account
+}
+
+func TestCreateAccountTx(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+
+	account, err := store.CreateAccountTx(context.Background(), CreateAccountParams{
+		Owner:    user.Username,
+		Currency: util.RandomCurrency(),
+		Kind:     AccountChecking,
+		Number:   util.RandomAccountNumber(),
+	})
+	require.NoError(t, err)
+
+	member, err := testQueries.GetAccountMember(context.Background(), GetAccountMemberParams{
+		AccountID: account.ID,
+		Username:  user.Username,
+	})
+	require.NoError(t, err)
+	require.Equal(t, MemberOwner, member.Role)
+	require.False(t, member.InvitedBy.Valid)
+}
+
+func TestAccountMembers(t *testing.T) {
+	account := createRandomAccount(t)
+	user := createRandomUser(t)
+
+	member, err := testQueries.CreateAccountMember(context.Background(), CreateAccountMemberParams{
+		AccountID: account.ID,
+		Username:  user.Username,
+		Role:      MemberCoOwner,
+		InvitedBy: sql.NullString{String: account.Owner, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, MemberCoOwner, member.Role)
+	require.Equal(t, account.Owner, member.InvitedBy.String)
+
+	accounts, err := testQueries.ListMemberAccounts(context.Background(), ListMemberAccountsParams{
+		Username: user.Username,
+		Limit:    5,
+	})
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+	require.Equal(t, account.ID, accounts[0].ID)
+
+	members, err := testQueries.ListAccountMembers(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Len(t, members, 1)
+
+	err = testQueries.DeleteAccountMember(context.Background(), DeleteAccountMemberParams{
+		AccountID: account.ID,
+		Username:  user.Username,
+	})
+	require.NoError(t, err)
+
+	_, err = testQueries.GetAccountMember(context.Background(), GetAccountMemberParams{
+		AccountID: account.ID,
+		Username:  user.Username,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
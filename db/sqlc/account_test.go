@@ -17,6 +17,8 @@ func createRandomAccount(t *testing.T) Account {
 		Owner:    user.Username,
 		Balance:  util.RandomMoney(),
 		Currency: util.RandomCurrency(),
+		Kind:     AccountChecking,
+		Number:   util.RandomAccountNumber(),
 	}
 
 	account, err := testQueries.CreateAccount(context.Background(), arg)
@@ -26,6 +28,9 @@ func createRandomAccount(t *testing.T) Account {
 	require.Equal(t, arg.Owner, account.Owner)
 	require.Equal(t, arg.Balance, account.Balance)
 	require.Equal(t, arg.Currency, account.Currency)
+	require.Equal(t, AccountActive, account.Status)
+	require.Equal(t, arg.Kind, account.Kind)
+	require.Equal(t, arg.Number, account.Number)
 
 	require.NotZero(t, account.ID)
 	require.NotZero(t, account.CreatedAt)
@@ -51,6 +56,17 @@ func TestGetAccount(t *testing.T) {
 	require.WithinDuration(t, expectedAccount.CreatedAt, actualAccount.CreatedAt, time.Second)
 }
 
+func TestGetAccountByNumber(t *testing.T) {
+	expectedAccount := createRandomAccount(t)
+
+	actualAccount, err := testQueries.GetAccountByNumber(context.Background(), expectedAccount.Number)
+	require.NoError(t, err)
+	require.Equal(t, expectedAccount.ID, actualAccount.ID)
+
+	_, err = testQueries.GetAccountByNumber(context.Background(), util.RandomAccountNumber())
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
 func TestListAccount(t *testing.T) {
 	var lastAccount Account
 	for i := 0; i < 10; i++ {
@@ -103,3 +119,67 @@ func TestDeleteAccount(t *testing.T) {
 	require.EqualError(t, err, sql.ErrNoRows.Error())
 	require.Empty(t, deletedAccount)
 }
+
+func TestUpdateAccountStatus(t *testing.T) {
+	account := createRandomAccount(t)
+
+	frozen, err := testQueries.UpdateAccountStatus(context.Background(), UpdateAccountStatusParams{
+		Status:        AccountFrozen,
+		ID:            account.ID,
+		CurrentStatus: AccountActive,
+	})
+	require.NoError(t, err)
+	require.Equal(t, AccountFrozen, frozen.Status)
+
+	// the transition only applies from the expected status
+	_, err = testQueries.UpdateAccountStatus(context.Background(), UpdateAccountStatusParams{
+		Status:        AccountFrozen,
+		ID:            account.ID,
+		CurrentStatus: AccountActive,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestCloseAccount(t *testing.T) {
+	account := createRandomAccount(t)
+
+	_, err := testQueries.CloseAccount(context.Background(), account.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	_, err = testQueries.UpdateAccount(context.Background(), UpdateAccountParams{ID: account.ID, Balance: 0})
+	require.NoError(t, err)
+
+	closed, err := testQueries.CloseAccount(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, AccountClosed, closed.Status)
+
+	_, err = testQueries.CloseAccount(context.Background(), account.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestCloseAccountWithStandingOrder(t *testing.T) {
+	account := createAccountWithCurrency(t, util.USD)
+	payee := createAccountWithCurrency(t, util.USD)
+
+	account, err := testQueries.UpdateAccount(context.Background(), UpdateAccountParams{ID: account.ID, Balance: 0})
+	require.NoError(t, err)
+
+	order := createRandomStandingOrder(t, account, payee, 10, InsufficientFundsSkip)
+
+	commitments, err := testQueries.GetAccountCommitments(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), commitments.ActiveStandingOrders)
+	require.Zero(t, commitments.ActiveHolds)
+	require.Zero(t, commitments.PendingScheduledTransfers)
+
+	// an empty account cannot be closed while a standing order still pays from it
+	_, err = testQueries.CloseAccount(context.Background(), account.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	_, err = testQueries.CancelStandingOrder(context.Background(), order.ID)
+	require.NoError(t, err)
+
+	closed, err := testQueries.CloseAccount(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, AccountClosed, closed.Status)
+}
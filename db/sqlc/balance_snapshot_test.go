@@ -0,0 +1,84 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/haniifac/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBalanceAsOfTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createAccountWithCurrency(t, util.USD)
+	account2 := createAccountWithCurrency(t, util.USD)
+	beforeTransfers := time.Now()
+
+	_, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+
+	// without a snapshot the balance is worked back from the current one
+	result, err := store.BalanceAsOfTx(context.Background(), BalanceAsOfTxParams{
+		AccountID: account1.ID,
+		AsOf:      time.Now(),
+	})
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance-10, result.Balance)
+	require.Equal(t, account1.Currency, result.Currency)
+	require.Nil(t, result.SnapshotTakenAt)
+
+	takenAt := time.Now().Truncate(time.Microsecond)
+	snapshot, err := testQueries.CreateBalanceSnapshot(context.Background(), CreateBalanceSnapshotParams{
+		TakenAt:   takenAt,
+		AccountID: account1.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance-10, snapshot.Balance)
+
+	// a second snapshot at the same time is not taken
+	_, err = testQueries.CreateBalanceSnapshot(context.Background(), CreateBalanceSnapshotParams{
+		TakenAt:   takenAt,
+		AccountID: account1.ID,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        5,
+	})
+	require.NoError(t, err)
+
+	result, err = store.BalanceAsOfTx(context.Background(), BalanceAsOfTxParams{
+		AccountID: account1.ID,
+		AsOf:      time.Now(),
+	})
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance-15, result.Balance)
+	require.NotNil(t, result.SnapshotTakenAt)
+	require.WithinDuration(t, takenAt, *result.SnapshotTakenAt, time.Microsecond)
+
+	result, err = store.BalanceAsOfTx(context.Background(), BalanceAsOfTxParams{
+		AccountID: account1.ID,
+		AsOf:      takenAt,
+	})
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance-10, result.Balance)
+
+	// dates before the first snapshot are worked back from it
+	result, err = store.BalanceAsOfTx(context.Background(), BalanceAsOfTxParams{
+		AccountID: account1.ID,
+		AsOf:      beforeTransfers,
+	})
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance, result.Balance)
+	require.NotNil(t, result.SnapshotTakenAt)
+	require.WithinDuration(t, takenAt, *result.SnapshotTakenAt, time.Microsecond)
+}
@@ -0,0 +1,52 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/haniifac/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomEmailVerification(t *testing.T, user User, expiresAt time.Time) EmailVerification {
+	verification, err := testQueries.CreateEmailVerification(context.Background(), CreateEmailVerificationParams{
+		Username:  user.Username,
+		Email:     user.Email,
+		TokenHash: util.HashSecret(util.RandomString(32)),
+		ExpiresAt: expiresAt,
+	})
+	require.NoError(t, err)
+	require.False(t, verification.UsedAt.Valid)
+
+	return verification
+}
+
+func TestVerifyEmailTx(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+
+	verification := createRandomEmailVerification(t, user, time.Now().Add(time.Hour))
+
+	verified, err := store.VerifyEmailTx(context.Background(), verification.TokenHash)
+	require.NoError(t, err)
+	require.True(t, verified.IsEmailVerified)
+
+	// the token can only be used once
+	_, err = store.VerifyEmailTx(context.Background(), verification.TokenHash)
+	require.ErrorIs(t, err, ErrVerificationTokenInvalid)
+}
+
+func TestVerifyEmailTxExpired(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+
+	verification := createRandomEmailVerification(t, user, time.Now().Add(-time.Minute))
+
+	_, err := store.VerifyEmailTx(context.Background(), verification.TokenHash)
+	require.ErrorIs(t, err, ErrVerificationTokenInvalid)
+
+	got, err := testQueries.GetUser(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.False(t, got.IsEmailVerified)
+}
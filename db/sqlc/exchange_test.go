@@ -0,0 +1,159 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/haniifac/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createAccountWithCurrency(t *testing.T, currency string) Account {
+	user := createRandomUser(t)
+
+	account, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    user.Username,
+		Balance:  util.RandomMoney(),
+		Currency: currency,
+		Kind:     AccountChecking,
+		Number:   util.RandomAccountNumber(),
+	})
+	require.NoError(t, err)
+
+	return account
+}
+
+func TestExchangeTransferTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createAccountWithCurrency(t, util.USD)
+	account2 := createAccountWithCurrency(t, util.EUR)
+
+	_, err := testQueries.UpsertExchangeRate(context.Background(), UpsertExchangeRateParams{
+		FromCurrency: util.USD,
+		ToCurrency:   util.EUR,
+		Rate:         "0.5",
+	})
+	require.NoError(t, err)
+
+	quote, err := testQueries.CreateExchangeQuote(context.Background(), CreateExchangeQuoteParams{
+		ID:           uuid.New(),
+		Username:     account1.Owner,
+		FromCurrency: util.USD,
+		ToCurrency:   util.EUR,
+		Rate:         "0.5",
+		ExpiresAt:    time.Now().Add(time.Minute),
+	})
+	require.NoError(t, err)
+
+	arg := ExchangeTransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+		QuoteID:       quote.ID,
+		Username:      account1.Owner,
+	}
+
+	result, err := store.ExchangeTransferTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, int64(10), result.Transfer.Amount)
+	require.True(t, result.Transfer.ToAmount.Valid)
+	require.Equal(t, int64(5), result.Transfer.ToAmount.Int64)
+	require.Equal(t, int64(-10), result.FromEntry.Amount)
+	require.Equal(t, int64(5), result.ToEntry.Amount)
+	require.Equal(t, account1.Balance-10, result.FromAccount.Balance)
+	require.Equal(t, account2.Balance+5, result.ToAccount.Balance)
+
+	// a quote can only be used once
+	_, err = store.ExchangeTransferTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrQuoteUsed)
+}
+
+func TestExchangeTransferTxExpiredQuote(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createAccountWithCurrency(t, util.USD)
+	account2 := createAccountWithCurrency(t, util.CAD)
+
+	quote, err := testQueries.CreateExchangeQuote(context.Background(), CreateExchangeQuoteParams{
+		ID:           uuid.New(),
+		Username:     account1.Owner,
+		FromCurrency: util.USD,
+		ToCurrency:   util.CAD,
+		Rate:         "1.35",
+		ExpiresAt:    time.Now().Add(-time.Second),
+	})
+	require.NoError(t, err)
+
+	_, err = store.ExchangeTransferTx(context.Background(), ExchangeTransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+		QuoteID:       quote.ID,
+		Username:      account1.Owner,
+	})
+	require.ErrorIs(t, err, ErrQuoteExpired)
+}
+
+func TestReverseExchangeTransferTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createAccountWithCurrency(t, util.USD)
+	account2 := createAccountWithCurrency(t, util.EUR)
+
+	quote, err := testQueries.CreateExchangeQuote(context.Background(), CreateExchangeQuoteParams{
+		ID:           uuid.New(),
+		Username:     account1.Owner,
+		FromCurrency: util.USD,
+		ToCurrency:   util.EUR,
+		Rate:         "0.5",
+		ExpiresAt:    time.Now().Add(time.Minute),
+	})
+	require.NoError(t, err)
+
+	transfer, err := store.ExchangeTransferTx(context.Background(), ExchangeTransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        11,
+		QuoteID:       quote.ID,
+		Username:      account1.Owner,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(5), transfer.Transfer.ToAmount.Int64)
+
+	// the receiver is debited in its own currency, the sender credited in the currency it sent
+	partial, err := store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: transfer.Transfer.ID,
+		Amount:     4,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), partial.Transfer.Amount)
+	require.Equal(t, int64(4), partial.Transfer.ToAmount.Int64)
+	require.Equal(t, "2.0000000000", partial.Transfer.ExchangeRate.String)
+	require.Equal(t, int64(-2), partial.FromEntry.Amount)
+	require.Equal(t, int64(4), partial.ToEntry.Amount)
+
+	_, err = store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: transfer.Transfer.ID,
+		Amount:     8,
+	})
+	require.ErrorIs(t, err, ErrReversalExceedsTotal)
+
+	// the last reversal takes back what is left of the converted amount
+	rest, err := store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: transfer.Transfer.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), rest.Transfer.Amount)
+	require.Equal(t, int64(7), rest.Transfer.ToAmount.Int64)
+
+	updatedAccount1, err := testQueries.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance, updatedAccount1.Balance)
+
+	updatedAccount2, err := testQueries.GetAccount(context.Background(), account2.ID)
+	require.NoError(t, err)
+	require.Equal(t, account2.Balance, updatedAccount2.Balance)
+}
@@ -0,0 +1,266 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/haniifac/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createFeeAccount(t *testing.T, schedule FeeSchedule) (Account, Account) {
+	revenue := createAccountWithCurrency(t, schedule.Currency)
+	_, err := testQueries.UpsertBankAccount(context.Background(), UpsertBankAccountParams{
+		Purpose:   BankFeeRevenue,
+		Currency:  schedule.Currency,
+		AccountID: revenue.ID,
+	})
+	require.NoError(t, err)
+
+	account := createAccountWithCurrency(t, schedule.Currency)
+	account, err = testQueries.UpdateAccountFeeSchedule(context.Background(), UpdateAccountFeeScheduleParams{
+		ID:            account.ID,
+		FeeScheduleID: sql.NullInt64{Int64: schedule.ID, Valid: true},
+	})
+	require.NoError(t, err)
+
+	return account, revenue
+}
+
+func TestTransferTxFee(t *testing.T) {
+	store := NewStore(testDB)
+
+	schedule, err := testQueries.CreateFeeSchedule(context.Background(), CreateFeeScheduleParams{
+		Name:        "per transfer",
+		Currency:    util.USD,
+		TransferFee: 25,
+	})
+	require.NoError(t, err)
+
+	account, revenue := createFeeAccount(t, schedule)
+	payee := createAccountWithCurrency(t, util.USD)
+
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account.ID,
+		ToAccountID:   payee.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, result.Fee)
+	require.Equal(t, FeeTransfer, result.Fee.Kind)
+	require.Equal(t, int64(25), result.Fee.Amount)
+	require.Equal(t, result.Transfer.ID, result.Fee.TransferID.Int64)
+	require.Equal(t, account.Balance-35, result.FromAccount.Balance)
+
+	updated, err := testQueries.GetAccount(context.Background(), revenue.ID)
+	require.NoError(t, err)
+	require.Equal(t, revenue.Balance+25, updated.Balance)
+
+	// the transfer is rolled back when the fee cannot be paid
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account.ID,
+		ToAccountID:   payee.ID,
+		Amount:        result.FromAccount.Balance,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	updated, err = testQueries.GetAccount(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, result.FromAccount.Balance, updated.Balance)
+}
+
+func TestTransferTxFeeDeadlock(t *testing.T) {
+	store := NewStore(testDB)
+
+	schedule, err := testQueries.CreateFeeSchedule(context.Background(), CreateFeeScheduleParams{
+		Name:        "per transfer",
+		Currency:    util.USD,
+		TransferFee: 1,
+	})
+	require.NoError(t, err)
+
+	account, revenue := createFeeAccount(t, schedule)
+	payee := createAccountWithCurrency(t, util.USD)
+
+	errs := make(chan error)
+
+	// transfers from the fee account lock the revenue account too, while transfers from the revenue account
+	// lock the fee account, which deadlocks unless both lock in the same order
+	n := 10
+	var amount int64 = 10
+	for i := 0; i < n; i++ {
+		go func() {
+			arg := TransferTxParams{
+				FromAccountID: account.ID,
+				ToAccountID:   payee.ID,
+				Amount:        amount,
+			}
+			if i%2 == 1 {
+				arg.FromAccountID = revenue.ID
+				arg.ToAccountID = account.ID
+			}
+
+			_, err := store.TransferTx(context.Background(), arg)
+			errs <- err
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		require.NoError(t, <-errs)
+	}
+
+	updated, err := testQueries.GetAccount(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, account.Balance-int64(n/2)*schedule.TransferFee, updated.Balance)
+
+	updated, err = testQueries.GetAccount(context.Background(), revenue.ID)
+	require.NoError(t, err)
+	require.Equal(t, revenue.Balance-int64(n/2)*(amount-schedule.TransferFee), updated.Balance)
+}
+
+func TestCaptureHoldTxFee(t *testing.T) {
+	store := NewStore(testDB)
+
+	schedule, err := testQueries.CreateFeeSchedule(context.Background(), CreateFeeScheduleParams{
+		Name:        "per transfer",
+		Currency:    util.USD,
+		TransferFee: 25,
+	})
+	require.NoError(t, err)
+
+	account, _ := createFeeAccount(t, schedule)
+	merchant := createAccountWithCurrency(t, util.USD)
+
+	hold, err := store.CreateHoldTx(context.Background(), CreateHoldTxParams{
+		AccountID:   account.ID,
+		ToAccountID: merchant.ID,
+		Amount:      10,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	// capturing a hold moves money out of the account like any other transfer
+	result, err := store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{HoldID: hold.ID})
+	require.NoError(t, err)
+	require.NotNil(t, result.Transfer.Fee)
+	require.Equal(t, int64(25), result.Transfer.Fee.Amount)
+	require.Equal(t, account.Balance-35, result.Transfer.FromAccount.Balance)
+}
+
+func TestChargePeriodicFeesTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	schedule, err := testQueries.CreateFeeSchedule(context.Background(), CreateFeeScheduleParams{
+		Name:            "maintenance",
+		Currency:        util.USD,
+		MonthlyFee:      5,
+		MinimumBalance:  util.RandomMoney() + 1000,
+		BelowMinimumFee: 3,
+	})
+	require.NoError(t, err)
+
+	account, _ := createFeeAccount(t, schedule)
+
+	periodEnd := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	arg := ChargePeriodicFeesTxParams{AccountID: account.ID, PeriodEnd: periodEnd}
+
+	charges, err := store.ChargePeriodicFeesTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, charges, 2)
+	require.Equal(t, FeeMonthly, charges[0].Kind)
+	require.Equal(t, FeeBelowMinimum, charges[1].Kind)
+
+	// fees are charged once per month
+	charges, err = store.ChargePeriodicFeesTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Empty(t, charges)
+
+	updated, err := testQueries.GetAccount(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, account.Balance-8, updated.Balance)
+}
+
+func TestChargePeriodicFeesTxPartial(t *testing.T) {
+	store := NewStore(testDB)
+
+	schedule, err := testQueries.CreateFeeSchedule(context.Background(), CreateFeeScheduleParams{
+		Name:            "maintenance",
+		Currency:        util.USD,
+		MonthlyFee:      5,
+		MinimumBalance:  1000,
+		BelowMinimumFee: 10,
+	})
+	require.NoError(t, err)
+
+	account, _ := createFeeAccount(t, schedule)
+	account, err = testQueries.UpdateAccount(context.Background(), UpdateAccountParams{ID: account.ID, Balance: 12})
+	require.NoError(t, err)
+
+	periodEnd := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	arg := ChargePeriodicFeesTxParams{AccountID: account.ID, PeriodEnd: periodEnd}
+
+	// the monthly fee is charged even though the below minimum fee cannot be paid
+	charges, err := store.ChargePeriodicFeesTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+	require.Len(t, charges, 1)
+	require.Equal(t, FeeMonthly, charges[0].Kind)
+
+	updated, err := testQueries.GetAccount(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(7), updated.Balance)
+}
+
+func TestChargePeriodicFeesTxFrozen(t *testing.T) {
+	store := NewStore(testDB)
+
+	schedule, err := testQueries.CreateFeeSchedule(context.Background(), CreateFeeScheduleParams{
+		Name:       "maintenance",
+		Currency:   util.USD,
+		MonthlyFee: 5,
+	})
+	require.NoError(t, err)
+
+	frozen, _ := createFeeAccount(t, schedule)
+	frozen, err = testQueries.UpdateAccountStatus(context.Background(), UpdateAccountStatusParams{
+		Status:        AccountFrozen,
+		ID:            frozen.ID,
+		CurrentStatus: AccountActive,
+	})
+	require.NoError(t, err)
+
+	closed, _ := createFeeAccount(t, schedule)
+	_, err = testQueries.UpdateAccount(context.Background(), UpdateAccountParams{ID: closed.ID, Balance: 0})
+	require.NoError(t, err)
+	_, err = testQueries.CloseAccount(context.Background(), closed.ID)
+	require.NoError(t, err)
+
+	// frozen accounts keep paying their fees, closed accounts are skipped
+	periodEnd := frozen.FeesChargedUntil.Time.AddDate(0, 1, 0)
+	accounts, err := testQueries.ListFeeAccounts(context.Background(), ListFeeAccountsParams{
+		PeriodEnd: periodEnd,
+		AfterID:   frozen.ID - 1,
+		Limit:     int32(closed.ID - frozen.ID + 1),
+	})
+	require.NoError(t, err)
+
+	ids := make([]int64, len(accounts))
+	for i, account := range accounts {
+		ids[i] = account.ID
+	}
+	require.Contains(t, ids, frozen.ID)
+	require.NotContains(t, ids, closed.ID)
+
+	charges, err := store.ChargePeriodicFeesTx(context.Background(), ChargePeriodicFeesTxParams{
+		AccountID: frozen.ID,
+		PeriodEnd: periodEnd,
+	})
+	require.NoError(t, err)
+	require.Len(t, charges, 1)
+
+	updated, err := testQueries.GetAccount(context.Background(), frozen.ID)
+	require.NoError(t, err)
+	require.Equal(t, frozen.Balance-5, updated.Balance)
+	require.Equal(t, AccountFrozen, updated.Status)
+}
@@ -0,0 +1,112 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/haniifac/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateHoldTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	payer := createAccountWithCurrency(t, util.USD)
+	merchant := createAccountWithCurrency(t, util.USD)
+
+	hold, err := store.CreateHoldTx(context.Background(), CreateHoldTxParams{
+		AccountID:   payer.ID,
+		ToAccountID: merchant.ID,
+		Amount:      payer.Balance,
+		Description: "card payment",
+		ExpiresAt:   time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+	require.Equal(t, HoldActive, hold.Status)
+
+	held, err := testQueries.GetHeldAmount(context.Background(), payer.ID)
+	require.NoError(t, err)
+	require.Equal(t, payer.Balance, held)
+
+	// the held funds are no longer available to transfers or other holds
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: payer.ID,
+		ToAccountID:   merchant.ID,
+		Amount:        1,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	_, err = store.CreateHoldTx(context.Background(), CreateHoldTxParams{
+		AccountID:   payer.ID,
+		ToAccountID: merchant.ID,
+		Amount:      1,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+}
+
+func TestCaptureHoldTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	payer := createAccountWithCurrency(t, util.USD)
+	merchant := createAccountWithCurrency(t, util.USD)
+
+	hold, err := store.CreateHoldTx(context.Background(), CreateHoldTxParams{
+		AccountID:   payer.ID,
+		ToAccountID: merchant.ID,
+		Amount:      10,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	_, err = store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{HoldID: hold.ID, Amount: 11})
+	require.ErrorIs(t, err, ErrCaptureExceedsHold)
+
+	result, err := store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{HoldID: hold.ID, Amount: 6})
+	require.NoError(t, err)
+	require.Equal(t, HoldCaptured, result.Hold.Status)
+	require.Equal(t, int64(6), result.Hold.CapturedAmount.Int64)
+	require.Equal(t, result.Transfer.Transfer.ID, result.Hold.TransferID.Int64)
+	require.Equal(t, payer.Balance-6, result.Transfer.FromAccount.Balance)
+	require.Equal(t, merchant.Balance+6, result.Transfer.ToAccount.Balance)
+
+	// the uncaptured rest is released with the hold
+	held, err := testQueries.GetHeldAmount(context.Background(), payer.ID)
+	require.NoError(t, err)
+	require.Zero(t, held)
+
+	_, err = store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{HoldID: hold.ID})
+	require.ErrorIs(t, err, ErrHoldNotActive)
+}
+
+func TestExpireHolds(t *testing.T) {
+	store := NewStore(testDB)
+
+	payer := createAccountWithCurrency(t, util.USD)
+	merchant := createAccountWithCurrency(t, util.USD)
+
+	hold, err := testQueries.CreateHold(context.Background(), CreateHoldParams{
+		AccountID:   payer.ID,
+		ToAccountID: merchant.ID,
+		Amount:      10,
+		ExpiresAt:   time.Now().Add(-time.Minute),
+	})
+	require.NoError(t, err)
+
+	_, err = store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{HoldID: hold.ID})
+	require.ErrorIs(t, err, ErrHoldExpired)
+
+	expired, err := testQueries.ExpireHolds(context.Background())
+	require.NoError(t, err)
+
+	var found bool
+	for _, h := range expired {
+		if h.ID == hold.ID {
+			found = true
+			require.Equal(t, HoldExpired, h.Status)
+			require.True(t, h.ResolvedAt.Valid)
+		}
+	}
+	require.True(t, found)
+}
@@ -0,0 +1,82 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/haniifac/simplebank/interest"
+	"github.com/haniifac/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterestTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	user := createRandomUser(t)
+	savings, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    user.Username,
+		Balance:  36500,
+		Currency: util.USD,
+		Kind:     AccountSavings,
+		Number:   util.RandomAccountNumber(),
+	})
+	require.NoError(t, err)
+
+	expense := createAccountWithCurrency(t, util.USD)
+	_, err = testQueries.UpsertBankAccount(context.Background(), UpsertBankAccountParams{
+		Purpose:   BankInterestExpense,
+		Currency:  util.USD,
+		AccountID: expense.ID,
+	})
+	require.NoError(t, err)
+
+	// 36500 * 0.01 / 365 is exactly one minor unit a day
+	rate := big.NewRat(1, 100)
+	start := time.Date(2024, time.January, 30, 0, 0, 0, 0, time.UTC)
+	for day := start; day.Month() == time.January; day = day.AddDate(0, 0, 1) {
+		accrual, err := store.AccrueInterestTx(context.Background(), AccrueInterestTxParams{
+			AccountID: savings.ID,
+			Date:      day,
+			Rate:      rate,
+			DayCount:  interest.Actual365,
+		})
+		require.NoError(t, err)
+		require.Equal(t, int64(36500), accrual.Balance)
+	}
+
+	// a day is only accrued once
+	_, err = store.AccrueInterestTx(context.Background(), AccrueInterestTxParams{
+		AccountID: savings.ID,
+		Date:      start,
+		Rate:      rate,
+		DayCount:  interest.Actual365,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	periodEnd := time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)
+	result, err := store.PostInterestTx(context.Background(), PostInterestTxParams{
+		AccountID: savings.ID,
+		PeriodEnd: periodEnd,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), result.Posting.Amount)
+	require.True(t, result.Posting.JournalID.Valid)
+	require.Len(t, result.Journal.Entries, 2)
+
+	updated, err := testQueries.GetAccount(context.Background(), savings.ID)
+	require.NoError(t, err)
+	require.Equal(t, savings.Balance+2, updated.Balance)
+
+	updated, err = testQueries.GetAccount(context.Background(), expense.ID)
+	require.NoError(t, err)
+	require.Equal(t, expense.Balance-2, updated.Balance)
+
+	_, err = store.PostInterestTx(context.Background(), PostInterestTxParams{
+		AccountID: savings.ID,
+		PeriodEnd: periodEnd,
+	})
+	require.ErrorIs(t, err, ErrNoInterestAccrued)
+}
@@ -0,0 +1,166 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/haniifac/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostJournalTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	payer := createAccountWithCurrency(t, util.USD)
+	payee1 := createAccountWithCurrency(t, util.USD)
+	payee2 := createAccountWithCurrency(t, util.USD)
+
+	arg := PostJournalTxParams{
+		Description: "split payment",
+		Postings: []Posting{
+			{AccountID: payer.ID, Amount: -30},
+			{AccountID: payee2.ID, Amount: 20},
+			{AccountID: payee1.ID, Amount: 10},
+		},
+	}
+
+	result, err := store.PostJournalTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, result.Journal.ID)
+	require.Equal(t, arg.Description, result.Journal.Description)
+
+	require.Len(t, result.Entries, len(arg.Postings))
+	for i, entry := range result.Entries {
+		require.Equal(t, arg.Postings[i].AccountID, entry.AccountID)
+		require.Equal(t, arg.Postings[i].Amount, entry.Amount)
+		require.Equal(t, sql.NullInt64{Int64: result.Journal.ID, Valid: true}, entry.JournalID)
+	}
+
+	require.Len(t, result.Accounts, 3)
+	for i := 1; i < len(result.Accounts); i++ {
+		require.Less(t, result.Accounts[i-1].ID, result.Accounts[i].ID)
+	}
+
+	updated, err := testQueries.GetAccount(context.Background(), payer.ID)
+	require.NoError(t, err)
+	require.Equal(t, payer.Balance-30, updated.Balance)
+
+	entries, err := testQueries.ListJournalEntries(context.Background(), sql.NullInt64{Int64: result.Journal.ID, Valid: true})
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+}
+
+func TestPostJournalTxUnbalanced(t *testing.T) {
+	store := NewStore(testDB)
+
+	usd1 := createAccountWithCurrency(t, util.USD)
+	usd2 := createAccountWithCurrency(t, util.USD)
+	eur := createAccountWithCurrency(t, util.EUR)
+
+	// balanced in total but not per currency
+	_, err := store.PostJournalTx(context.Background(), PostJournalTxParams{
+		Description: "unbalanced",
+		Postings: []Posting{
+			{AccountID: usd1.ID, Amount: -10},
+			{AccountID: usd2.ID, Amount: 5},
+			{AccountID: eur.ID, Amount: 5},
+		},
+	})
+	require.ErrorIs(t, err, ErrUnbalancedJournal)
+
+	_, err = store.PostJournalTx(context.Background(), PostJournalTxParams{
+		Description: "overdrawn",
+		Postings: []Posting{
+			{AccountID: usd1.ID, Amount: -(usd1.Balance + 1)},
+			{AccountID: usd2.ID, Amount: usd1.Balance + 1},
+		},
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	account, err := testQueries.GetAccount(context.Background(), usd1.ID)
+	require.NoError(t, err)
+	require.Equal(t, usd1.Balance, account.Balance)
+}
+
+func TestPostJournalTxAccountChecks(t *testing.T) {
+	store := NewStore(testDB)
+
+	payer := createAccountWithCurrency(t, util.USD)
+	payee := createAccountWithCurrency(t, util.USD)
+	merchant := createAccountWithCurrency(t, util.USD)
+
+	// funds reserved by a hold cannot be debited
+	_, err := store.CreateHoldTx(context.Background(), CreateHoldTxParams{
+		AccountID:   payer.ID,
+		ToAccountID: merchant.ID,
+		Amount:      10,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	_, err = store.PostJournalTx(context.Background(), PostJournalTxParams{
+		Description: "held funds",
+		Postings: []Posting{
+			{AccountID: payer.ID, Amount: -(payer.Balance - 5)},
+			{AccountID: payee.ID, Amount: payer.Balance - 5},
+		},
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	// frozen accounts can neither be debited nor credited
+	_, err = testQueries.UpdateAccountStatus(context.Background(), UpdateAccountStatusParams{
+		Status:        AccountFrozen,
+		ID:            payee.ID,
+		CurrentStatus: AccountActive,
+	})
+	require.NoError(t, err)
+
+	_, err = store.PostJournalTx(context.Background(), PostJournalTxParams{
+		Description: "frozen",
+		Postings: []Posting{
+			{AccountID: payer.ID, Amount: -1},
+			{AccountID: payee.ID, Amount: 1},
+		},
+	})
+	require.ErrorIs(t, err, ErrAccountNotActive)
+}
+
+func TestPostJournalTxDeadlock(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createAccountWithCurrency(t, util.USD)
+	account2 := createAccountWithCurrency(t, util.USD)
+	account3 := createAccountWithCurrency(t, util.USD)
+
+	// rotate the posting order so every goroutine lists the accounts differently
+	accounts := []Account{account1, account2, account3}
+	n := 9
+	errs := make(chan error)
+	for i := 0; i < n; i++ {
+		from, to1, to2 := accounts[i%3], accounts[(i+1)%3], accounts[(i+2)%3]
+		go func() {
+			_, err := store.PostJournalTx(context.Background(), PostJournalTxParams{
+				Description: "rotation",
+				Postings: []Posting{
+					{AccountID: from.ID, Amount: -2},
+					{AccountID: to1.ID, Amount: 1},
+					{AccountID: to2.ID, Amount: 1},
+				},
+			})
+			errs <- err
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		require.NoError(t, <-errs)
+	}
+
+	// every account paid and received the same amount
+	for _, account := range accounts {
+		updated, err := testQueries.GetAccount(context.Background(), account.ID)
+		require.NoError(t, err)
+		require.Equal(t, account.Balance, updated.Balance)
+	}
+}
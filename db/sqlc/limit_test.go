@@ -0,0 +1,76 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/haniifac/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferLimits(t *testing.T) {
+	options := DefaultTxOptions()
+	options.TransferLimits = TransferLimits{Monthly: 100}
+	store := NewStoreWithOptions(testDB, options)
+
+	account1 := createAccountWithCurrency(t, util.USD)
+	account2 := createAccountWithCurrency(t, util.USD)
+
+	_, err := testQueries.UpdateAccountTransferLimits(context.Background(), UpdateAccountTransferLimitsParams{
+		ID:         account1.ID,
+		DailyLimit: sql.NullInt64{Int64: 30, Valid: true},
+	})
+	require.NoError(t, err)
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        20,
+	})
+	require.NoError(t, err)
+
+	// the daily limit of the account applies before the default monthly limit
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        11,
+	})
+	require.ErrorIs(t, err, ErrTransferLimitExceeded)
+
+	limits, err := store.AccountLimitsTx(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(20), limits.Daily.Used)
+	require.Equal(t, int64(10), *limits.Daily.Remaining)
+	require.Equal(t, int64(100), *limits.Monthly.Limit)
+	require.Equal(t, int64(80), *limits.Monthly.Remaining)
+	require.True(t, limits.Daily.ResetsAt.After(time.Now()))
+
+	// the receiving account has no limit of its own and only the default monthly one
+	limits, err = store.AccountLimitsTx(context.Background(), account2.ID)
+	require.NoError(t, err)
+	require.Nil(t, limits.Daily.Limit)
+	require.Equal(t, int64(0), limits.Monthly.Used)
+}
+
+func TestLimitUsage(t *testing.T) {
+	resetsAt := time.Now()
+
+	usage := limitUsage(sql.NullInt64{}, 0, 50, resetsAt)
+	require.Nil(t, usage.Limit)
+	require.True(t, usage.allows(1_000_000))
+
+	usage = limitUsage(sql.NullInt64{}, 100, 50, resetsAt)
+	require.Equal(t, int64(50), *usage.Remaining)
+	require.True(t, usage.allows(50))
+	require.False(t, usage.allows(51))
+
+	// a limit of 0 set on the account blocks all outgoing transfers
+	usage = limitUsage(sql.NullInt64{Int64: 0, Valid: true}, 100, 0, resetsAt)
+	require.False(t, usage.allows(1))
+
+	// the remaining amount does not go below zero when the limit was lowered after use
+	usage = limitUsage(sql.NullInt64{Int64: 10, Valid: true}, 0, 50, resetsAt)
+	require.Equal(t, int64(0), *usage.Remaining)
+}
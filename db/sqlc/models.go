@@ -5,6 +5,8 @@
 package db
 
 import (
+	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -16,6 +18,58 @@ type Account struct {
 	Balance   int64     `json:"balance"`
 	Currency  string    `json:"currency"`
 	CreatedAt time.Time `json:"created_at"`
+	// active, frozen or closed, only active accounts can send or receive money
+	Status string `json:"status"`
+	// how far below zero the balance may go, set by admins
+	OverdraftLimit int64 `json:"overdraft_limit"`
+	// checking or savings, only savings accounts earn interest
+	Kind          string        `json:"kind"`
+	FeeScheduleID sql.NullInt64 `json:"fee_schedule_id"`
+	// external IBAN style account number with mod 97 check digits
+	Number string `json:"number"`
+	// most that may leave the account per UTC day, null uses the configured default
+	DailyLimit sql.NullInt64 `json:"daily_limit"`
+	// most that may leave the account per UTC month, null uses the configured default
+	MonthlyLimit sql.NullInt64 `json:"monthly_limit"`
+	// first day after the last month whose periodic fees are all charged, null without a fee schedule
+	FeesChargedUntil sql.NullTime `json:"fees_charged_until"`
+}
+
+type AccountMember struct {
+	AccountID int64  `json:"account_id"`
+	Username  string `json:"username"`
+	// owner, co_owner or viewer
+	Role string `json:"role"`
+	// member that added the user, null for the owner that opened the account
+	InvitedBy sql.NullString `json:"invited_by"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+type BalanceSnapshot struct {
+	AccountID int64     `json:"account_id"`
+	TakenAt   time.Time `json:"taken_at"`
+	// balance of the account from the entries created before taken_at
+	Balance   int64     `json:"balance"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type BankAccount struct {
+	// what the bank owned account books, such as interest_expense
+	Purpose   string `json:"purpose"`
+	Currency  string `json:"currency"`
+	AccountID int64  `json:"account_id"`
+}
+
+type EmailVerification struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+	// address the token was sent to, it only verifies the user while they still have it
+	Email string `json:"email"`
+	// sha256 of the token sent by email, the token itself is never stored
+	TokenHash string       `json:"token_hash"`
+	ExpiresAt time.Time    `json:"expires_at"`
+	UsedAt    sql.NullTime `json:"used_at"`
+	CreatedAt time.Time    `json:"created_at"`
 }
 
 type Entry struct {
@@ -24,6 +78,164 @@ type Entry struct {
 	// can be negative or positive
 	Amount    int64     `json:"amount"`
 	CreatedAt time.Time `json:"created_at"`
+	// journal the entry was posted with, the entries of a journal sum to zero per currency
+	JournalID sql.NullInt64 `json:"journal_id"`
+}
+
+type ExchangeQuote struct {
+	ID           uuid.UUID `json:"id"`
+	Username     string    `json:"username"`
+	FromCurrency string    `json:"from_currency"`
+	ToCurrency   string    `json:"to_currency"`
+	// rate locked until expires_at
+	Rate      string       `json:"rate"`
+	ExpiresAt time.Time    `json:"expires_at"`
+	UsedAt    sql.NullTime `json:"used_at"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+type ExchangeRate struct {
+	FromCurrency string `json:"from_currency"`
+	ToCurrency   string `json:"to_currency"`
+	// units of to_currency for one unit of from_currency
+	Rate      string    `json:"rate"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type FeeCharge struct {
+	ID            int64 `json:"id"`
+	AccountID     int64 `json:"account_id"`
+	FeeScheduleID int64 `json:"fee_schedule_id"`
+	// transfer, monthly or below_minimum
+	Kind   string `json:"kind"`
+	Amount int64  `json:"amount"`
+	// first day after the month a periodic fee was charged for
+	PeriodEnd sql.NullTime `json:"period_end"`
+	// transfer a transfer fee was charged for
+	TransferID sql.NullInt64 `json:"transfer_id"`
+	JournalID  int64         `json:"journal_id"`
+	CreatedAt  time.Time     `json:"created_at"`
+}
+
+type FeeSchedule struct {
+	ID       int64  `json:"id"`
+	Name     string `json:"name"`
+	Currency string `json:"currency"`
+	// maintenance fee charged for every month
+	MonthlyFee int64 `json:"monthly_fee"`
+	// charged on every outgoing transfer
+	TransferFee    int64 `json:"transfer_fee"`
+	MinimumBalance int64 `json:"minimum_balance"`
+	// charged for a month ending with a balance below minimum_balance
+	BelowMinimumFee int64     `json:"below_minimum_fee"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+type Hold struct {
+	ID          int64 `json:"id"`
+	AccountID   int64 `json:"account_id"`
+	ToAccountID int64 `json:"to_account_id"`
+	// reserved on account_id until the hold is captured, released or expires
+	Amount      int64  `json:"amount"`
+	Description string `json:"description"`
+	// active, captured, released or expired
+	Status    string    `json:"status"`
+	ExpiresAt time.Time `json:"expires_at"`
+	// amount transferred to to_account_id, at most amount
+	CapturedAmount sql.NullInt64 `json:"captured_amount"`
+	TransferID     sql.NullInt64 `json:"transfer_id"`
+	ResolvedAt     sql.NullTime  `json:"resolved_at"`
+	CreatedAt      time.Time     `json:"created_at"`
+}
+
+type IdempotencyKey struct {
+	Username string `json:"username"`
+	Key      string `json:"key"`
+	// sha256 of the request body the key was first used with
+	RequestHash string `json:"request_hash"`
+	TransferID  int64  `json:"transfer_id"`
+	// transfer result returned on replay
+	Response  json.RawMessage `json:"response"`
+	CreatedAt time.Time       `json:"created_at"`
+	ExpiresAt time.Time       `json:"expires_at"`
+}
+
+type InterestAccrual struct {
+	ID          int64     `json:"id"`
+	AccountID   int64     `json:"account_id"`
+	AccrualDate time.Time `json:"accrual_date"`
+	// end of day balance interest was accrued on
+	Balance int64 `json:"balance"`
+	// annual interest rate, 0.02 is 2%
+	Rate string `json:"rate"`
+	// interest of the day in minor units, including fractions
+	Amount    string        `json:"amount"`
+	PostingID sql.NullInt64 `json:"posting_id"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+type InterestPosting struct {
+	ID        int64 `json:"id"`
+	AccountID int64 `json:"account_id"`
+	// accruals dated before period_end are included
+	PeriodEnd time.Time `json:"period_end"`
+	// sum of the accruals of the period plus the carry of the previous posting, in minor units
+	Accrued string `json:"accrued"`
+	// whole minor units credited to the account
+	Amount int64 `json:"amount"`
+	// fraction of a minor unit left over for the next posting
+	Carry     string        `json:"carry"`
+	JournalID sql.NullInt64 `json:"journal_id"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+type Journal struct {
+	ID          int64     `json:"id"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	// transfer the journal books, null for other journals
+	TransferID sql.NullInt64 `json:"transfer_id"`
+}
+
+// journals the reconciliation migration created for transfers booked before journals existed, removed again when it is rolled back
+type JournalBackfill struct {
+	JournalID int64 `json:"journal_id"`
+}
+
+type PasswordResetToken struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+	// sha256 of the token sent by email, the token itself is never stored
+	TokenHash string    `json:"token_hash"`
+	ExpiresAt time.Time `json:"expires_at"`
+	// when the token was used or invalidated, it cannot be used again
+	UsedAt    sql.NullTime `json:"used_at"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+type ReconciliationReport struct {
+	ID         int64     `json:"id"`
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+	// true when no mismatch or orphaned entry was found
+	Ok     bool            `json:"ok"`
+	Report json.RawMessage `json:"report"`
+}
+
+type ScheduledTransfer struct {
+	ID            int64  `json:"id"`
+	Owner         string `json:"owner"`
+	FromAccountID int64  `json:"from_account_id"`
+	ToAccountID   int64  `json:"to_account_id"`
+	// must be positive
+	Amount      int64     `json:"amount"`
+	ScheduledAt time.Time `json:"scheduled_at"`
+	// pending, executed, failed or cancelled
+	Status        string        `json:"status"`
+	TransferID    sql.NullInt64 `json:"transfer_id"`
+	FailureReason string        `json:"failure_reason"`
+	ExecutedAt    sql.NullTime  `json:"executed_at"`
+	CreatedAt     time.Time     `json:"created_at"`
 }
 
 type Session struct {
@@ -35,6 +247,46 @@ type Session struct {
 	CreatedAt    time.Time `json:"created_at"`
 	ExpiresAt    time.Time `json:"expires_at"`
 	IsBlocked    bool      `json:"is_blocked"`
+	// id of the login session the refresh token was rotated from
+	FamilyID uuid.UUID `json:"family_id"`
+	// when the refresh token was exchanged for a new one, it cannot be used again
+	UsedAt sql.NullTime `json:"used_at"`
+}
+
+type StandingOrder struct {
+	ID            int64  `json:"id"`
+	Owner         string `json:"owner"`
+	FromAccountID int64  `json:"from_account_id"`
+	ToAccountID   int64  `json:"to_account_id"`
+	// must be positive
+	Amount int64 `json:"amount"`
+	// weekly, monthly or end_of_month
+	Frequency string `json:"frequency"`
+	// day a monthly order runs on, clamped to the month length
+	DayOfMonth int32 `json:"day_of_month"`
+	// skip or retry
+	InsufficientFundsPolicy string `json:"insufficient_funds_policy"`
+	// next regular occurrence
+	NextRunAt time.Time `json:"next_run_at"`
+	// set while an occurrence that failed is waiting to be retried
+	RetryAt sql.NullTime `json:"retry_at"`
+	EndDate sql.NullTime `json:"end_date"`
+	// occurrences left, null when unlimited
+	RemainingCount sql.NullInt32 `json:"remaining_count"`
+	// active, completed or cancelled
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type StandingOrderExecution struct {
+	ID              int64     `json:"id"`
+	StandingOrderID int64     `json:"standing_order_id"`
+	ScheduledFor    time.Time `json:"scheduled_for"`
+	// succeeded, retrying or skipped
+	Status        string        `json:"status"`
+	TransferID    sql.NullInt64 `json:"transfer_id"`
+	FailureReason string        `json:"failure_reason"`
+	ExecutedAt    time.Time     `json:"executed_at"`
 }
 
 type Transfer struct {
@@ -44,6 +296,12 @@ type Transfer struct {
 	// must be positive
 	Amount    int64     `json:"amount"`
 	CreatedAt time.Time `json:"created_at"`
+	// original transfer this transfer reverses
+	ReversedTransferID sql.NullInt64 `json:"reversed_transfer_id"`
+	// amount credited in the destination currency, null when both accounts share a currency
+	ToAmount sql.NullInt64 `json:"to_amount"`
+	// rate applied to amount, null when both accounts share a currency
+	ExchangeRate sql.NullString `json:"exchange_rate"`
 }
 
 type User struct {
@@ -53,4 +311,6 @@ type User struct {
 	Email             string    `json:"email"`
 	CreatedAt         time.Time `json:"created_at"`
 	PasswordUpdatedAt time.Time `json:"password_updated_at"`
+	Role              string    `json:"role"`
+	IsEmailVerified   bool      `json:"is_email_verified"`
 }
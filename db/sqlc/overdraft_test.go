@@ -0,0 +1,48 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/haniifac/simplebank/util"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferTxOverdraft(t *testing.T) {
+	store := NewStore(testDB)
+
+	payer := createAccountWithCurrency(t, util.USD)
+	payee := createAccountWithCurrency(t, util.USD)
+
+	payer, err := testQueries.UpdateAccountOverdraftLimit(context.Background(), UpdateAccountOverdraftLimitParams{
+		ID:             payer.ID,
+		OverdraftLimit: 100,
+	})
+	require.NoError(t, err)
+
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: payer.ID,
+		ToAccountID:   payee.ID,
+		Amount:        payer.Balance + 100,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(-100), result.FromAccount.Balance)
+	require.Equal(t, int64(100), OverdraftInUse(result.FromAccount.Balance))
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: payer.ID,
+		ToAccountID:   payee.ID,
+		Amount:        1,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	// the limit cannot be lowered below the overdraft in use
+	_, err = testQueries.UpdateAccountOverdraftLimit(context.Background(), UpdateAccountOverdraftLimitParams{
+		ID:             payer.ID,
+		OverdraftLimit: 50,
+	})
+	var pqErr *pq.Error
+	require.ErrorAs(t, err, &pqErr)
+	require.Equal(t, "check_violation", pqErr.Code.Name())
+}
@@ -0,0 +1,71 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/haniifac/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomResetToken(t *testing.T, username string, expiresAt time.Time) PasswordResetToken {
+	resetToken, err := testQueries.CreatePasswordResetToken(context.Background(), CreatePasswordResetTokenParams{
+		Username:  username,
+		TokenHash: util.HashSecret(util.RandomString(32)),
+		ExpiresAt: expiresAt,
+	})
+	require.NoError(t, err)
+	require.False(t, resetToken.UsedAt.Valid)
+
+	return resetToken
+}
+
+func TestResetPasswordTx(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+
+	session, err := testQueries.CreateSession(context.Background(), newSessionParams(user.Username, uuid.Nil))
+	require.NoError(t, err)
+
+	resetToken := createRandomResetToken(t, user.Username, time.Now().Add(time.Hour))
+	other := createRandomResetToken(t, user.Username, time.Now().Add(time.Hour))
+
+	arg := ResetPasswordTxParams{
+		TokenHash:      resetToken.TokenHash,
+		HashedPassword: util.RandomString(10),
+	}
+	updated, err := store.ResetPasswordTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.HashedPassword, updated.HashedPassword)
+
+	session, err = testQueries.GetSession(context.Background(), session.ID)
+	require.NoError(t, err)
+	require.True(t, session.IsBlocked)
+
+	// the token can only be used once and the other tokens of the user are invalidated
+	_, err = store.ResetPasswordTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrResetTokenInvalid)
+
+	arg.TokenHash = other.TokenHash
+	_, err = store.ResetPasswordTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrResetTokenInvalid)
+}
+
+func TestResetPasswordTxExpired(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+
+	resetToken := createRandomResetToken(t, user.Username, time.Now().Add(-time.Minute))
+
+	_, err := store.ResetPasswordTx(context.Background(), ResetPasswordTxParams{
+		TokenHash:      resetToken.TokenHash,
+		HashedPassword: util.RandomString(10),
+	})
+	require.ErrorIs(t, err, ErrResetTokenInvalid)
+
+	got, err := testQueries.GetUser(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Equal(t, user.HashedPassword, got.HashedPassword)
+}
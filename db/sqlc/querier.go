@@ -6,29 +6,136 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type Querier interface {
 	AddAccountBalance(ctx context.Context, arg AddAccountBalanceParams) (Account, error)
+	BlockSessionFamily(ctx context.Context, familyID uuid.UUID) error
+	BlockUserSessions(ctx context.Context, username string) error
+	CancelScheduledTransfer(ctx context.Context, id int64) (ScheduledTransfer, error)
+	CancelStandingOrder(ctx context.Context, id int64) (StandingOrder, error)
+	// only empty accounts that no hold, scheduled transfer or standing order still moves money in or out of can be closed
+	CloseAccount(ctx context.Context, id int64) (Account, error)
 	CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error)
+	CreateAccountMember(ctx context.Context, arg CreateAccountMemberParams) (AccountMember, error)
+	CreateBalanceSnapshot(ctx context.Context, arg CreateBalanceSnapshotParams) (BalanceSnapshot, error)
+	CreateEmailVerification(ctx context.Context, arg CreateEmailVerificationParams) (EmailVerification, error)
 	CreateEntry(ctx context.Context, arg CreateEntryParams) (Entry, error)
+	CreateExchangeQuote(ctx context.Context, arg CreateExchangeQuoteParams) (ExchangeQuote, error)
+	CreateFeeCharge(ctx context.Context, arg CreateFeeChargeParams) (FeeCharge, error)
+	CreateFeeSchedule(ctx context.Context, arg CreateFeeScheduleParams) (FeeSchedule, error)
+	CreateHold(ctx context.Context, arg CreateHoldParams) (Hold, error)
+	CreateIdempotencyKey(ctx context.Context, arg CreateIdempotencyKeyParams) (IdempotencyKey, error)
+	CreateInterestAccrual(ctx context.Context, arg CreateInterestAccrualParams) (InterestAccrual, error)
+	CreateInterestPosting(ctx context.Context, arg CreateInterestPostingParams) (InterestPosting, error)
+	CreateJournal(ctx context.Context, description string) (Journal, error)
+	CreatePasswordResetToken(ctx context.Context, arg CreatePasswordResetTokenParams) (PasswordResetToken, error)
+	CreateReconciliationReport(ctx context.Context, arg CreateReconciliationReportParams) (ReconciliationReport, error)
+	CreateScheduledTransfer(ctx context.Context, arg CreateScheduledTransferParams) (ScheduledTransfer, error)
 	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
+	CreateStandingOrder(ctx context.Context, arg CreateStandingOrderParams) (StandingOrder, error)
+	CreateStandingOrderExecution(ctx context.Context, arg CreateStandingOrderExecutionParams) (StandingOrderExecution, error)
 	CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error)
+	CreateTransferJournal(ctx context.Context, arg CreateTransferJournalParams) (Journal, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
 	DeleteAccount(ctx context.Context, id int64) error
+	DeleteAccountMember(ctx context.Context, arg DeleteAccountMemberParams) error
+	ExpireHolds(ctx context.Context) ([]Hold, error)
 	GetAccount(ctx context.Context, id int64) (Account, error)
+	GetAccountBalanceAt(ctx context.Context, arg GetAccountBalanceAtParams) (int64, error)
+	GetAccountByNumber(ctx context.Context, number string) (Account, error)
+	// counts what still moves money in or out of an account, see CloseAccount
+	GetAccountCommitments(ctx context.Context, accountID int64) (GetAccountCommitmentsRow, error)
 	GetAccountForUpdate(ctx context.Context, id int64) (Account, error)
+	GetAccountMember(ctx context.Context, arg GetAccountMemberParams) (AccountMember, error)
+	GetAuthSession(ctx context.Context, id uuid.UUID) (GetAuthSessionRow, error)
+	GetBalanceSnapshotAfter(ctx context.Context, arg GetBalanceSnapshotAfterParams) (BalanceSnapshot, error)
+	GetBalanceSnapshotBefore(ctx context.Context, arg GetBalanceSnapshotBeforeParams) (BalanceSnapshot, error)
+	GetBankAccount(ctx context.Context, arg GetBankAccountParams) (BankAccount, error)
+	GetDueScheduledTransferForUpdate(ctx context.Context) (ScheduledTransfer, error)
+	GetDueStandingOrderForUpdate(ctx context.Context) (StandingOrder, error)
+	GetEntriesTotalBetween(ctx context.Context, arg GetEntriesTotalBetweenParams) (int64, error)
 	GetEntry(ctx context.Context, id int64) (Entry, error)
+	GetExchangeQuote(ctx context.Context, id uuid.UUID) (ExchangeQuote, error)
+	GetExchangeQuoteForUpdate(ctx context.Context, id uuid.UUID) (ExchangeQuote, error)
+	GetExchangeRate(ctx context.Context, arg GetExchangeRateParams) (ExchangeRate, error)
+	GetFeeSchedule(ctx context.Context, id int64) (FeeSchedule, error)
+	GetHeldAmount(ctx context.Context, accountID int64) (int64, error)
+	GetHold(ctx context.Context, id int64) (Hold, error)
+	GetHoldForUpdate(ctx context.Context, id int64) (Hold, error)
+	GetIdempotencyKey(ctx context.Context, arg GetIdempotencyKeyParams) (IdempotencyKey, error)
+	GetJournal(ctx context.Context, id int64) (Journal, error)
+	GetLastInterestAccrual(ctx context.Context, accountID int64) (InterestAccrual, error)
+	GetLastInterestPosting(ctx context.Context, accountID int64) (InterestPosting, error)
+	GetLatestReconciliationReport(ctx context.Context) (ReconciliationReport, error)
+	GetOutgoingTransferTotals(ctx context.Context, arg GetOutgoingTransferTotalsParams) (GetOutgoingTransferTotalsRow, error)
+	GetPeriodicFeeCharge(ctx context.Context, arg GetPeriodicFeeChargeParams) (FeeCharge, error)
+	// reversed_amount is what was returned to the sender of the transfer, reversed_to_amount what was taken back from its receiver
+	GetReversedAmount(ctx context.Context, transferID int64) (GetReversedAmountRow, error)
+	GetScheduledTransfer(ctx context.Context, id int64) (ScheduledTransfer, error)
 	GetSession(ctx context.Context, id uuid.UUID) (Session, error)
+	GetStandingOrder(ctx context.Context, id int64) (StandingOrder, error)
 	GetTransfer(ctx context.Context, id int64) (Transfer, error)
+	GetTransferForUpdate(ctx context.Context, id int64) (Transfer, error)
 	GetUser(ctx context.Context, username string) (User, error)
+	GetUserByEmail(ctx context.Context, email string) (User, error)
+	InvalidatePasswordResetTokens(ctx context.Context, username string) error
+	ListAccountEntryTotals(ctx context.Context, arg ListAccountEntryTotalsParams) ([]ListAccountEntryTotalsRow, error)
+	ListAccountMembers(ctx context.Context, accountID int64) ([]AccountMember, error)
+	ListAccountTransfers(ctx context.Context, arg ListAccountTransfersParams) ([]Transfer, error)
 	ListAccounts(ctx context.Context, arg ListAccountsParams) ([]Account, error)
+	ListActiveHolds(ctx context.Context, accountID int64) ([]Hold, error)
+	ListActiveSessions(ctx context.Context, username string) ([]Session, error)
 	ListEntries(ctx context.Context, arg ListEntriesParams) ([]Entry, error)
+	ListExchangeRates(ctx context.Context) ([]ExchangeRate, error)
+	// frozen accounts keep paying their fees, only closed accounts are skipped
+	ListFeeAccounts(ctx context.Context, arg ListFeeAccountsParams) ([]Account, error)
+	ListFeeCharges(ctx context.Context, arg ListFeeChargesParams) ([]FeeCharge, error)
+	ListFeeSchedules(ctx context.Context, arg ListFeeSchedulesParams) ([]FeeSchedule, error)
+	ListInterestAccounts(ctx context.Context, arg ListInterestAccountsParams) ([]Account, error)
+	ListJournalEntries(ctx context.Context, journalID sql.NullInt64) ([]Entry, error)
+	// a row per currency of each journal in the batch, exchange marks the journals of cross currency transfers
+	ListJournalCurrencyTotals(ctx context.Context, arg ListJournalCurrencyTotalsParams) ([]ListJournalCurrencyTotalsRow, error)
+	ListMemberAccounts(ctx context.Context, arg ListMemberAccountsParams) ([]Account, error)
+	ListOrphanedEntries(ctx context.Context, arg ListOrphanedEntriesParams) ([]Entry, error)
+	ListScheduledTransfers(ctx context.Context, arg ListScheduledTransfersParams) ([]ScheduledTransfer, error)
+	ListSnapshotAccountIds(ctx context.Context, arg ListSnapshotAccountIdsParams) ([]int64, error)
+	ListStandingOrderExecutions(ctx context.Context, arg ListStandingOrderExecutionsParams) ([]StandingOrderExecution, error)
+	ListStandingOrders(ctx context.Context, arg ListStandingOrdersParams) ([]StandingOrder, error)
+	ListStatementEntries(ctx context.Context, arg ListStatementEntriesParams) ([]ListStatementEntriesRow, error)
+	ListTransferEntryTotals(ctx context.Context, arg ListTransferEntryTotalsParams) ([]ListTransferEntryTotalsRow, error)
 	ListTransfers(ctx context.Context, arg ListTransfersParams) ([]Transfer, error)
 	ListTransfersByAccountId(ctx context.Context, arg ListTransfersByAccountIdParams) ([]Transfer, error)
+	ListUnpostedInterestAccountIds(ctx context.Context, periodEnd time.Time) ([]int64, error)
+	ListUnpostedInterestAccruals(ctx context.Context, arg ListUnpostedInterestAccrualsParams) ([]InterestAccrual, error)
+	// leaves orders that were cancelled or finished in the meantime untouched
+	PostponeStandingOrder(ctx context.Context, arg PostponeStandingOrderParams) error
+	ResolveHold(ctx context.Context, arg ResolveHoldParams) (Hold, error)
+	SetInterestAccrualsPosted(ctx context.Context, arg SetInterestAccrualsPostedParams) error
+	// only moves on from the month before, so a month whose fees could not be charged is tried again
+	SettleFeePeriod(ctx context.Context, arg SettleFeePeriodParams) error
 	UpdateAccount(ctx context.Context, arg UpdateAccountParams) (Account, error)
+	// periodic fees of an account that gets its first fee schedule start with the current month
+	UpdateAccountFeeSchedule(ctx context.Context, arg UpdateAccountFeeScheduleParams) (Account, error)
+	UpdateAccountOverdraftLimit(ctx context.Context, arg UpdateAccountOverdraftLimitParams) (Account, error)
+	UpdateAccountStatus(ctx context.Context, arg UpdateAccountStatusParams) (Account, error)
+	UpdateAccountTransferLimits(ctx context.Context, arg UpdateAccountTransferLimitsParams) (Account, error)
+	UpdateHoldTransfer(ctx context.Context, arg UpdateHoldTransferParams) (Hold, error)
+	UpdateScheduledTransferResult(ctx context.Context, arg UpdateScheduledTransferResultParams) (ScheduledTransfer, error)
+	UpdateStandingOrder(ctx context.Context, arg UpdateStandingOrderParams) (StandingOrder, error)
+	UpdateStandingOrderSchedule(ctx context.Context, arg UpdateStandingOrderScheduleParams) (StandingOrder, error)
+	UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) (User, error)
+	UpsertBankAccount(ctx context.Context, arg UpsertBankAccountParams) (BankAccount, error)
+	UpsertExchangeRate(ctx context.Context, arg UpsertExchangeRateParams) (ExchangeRate, error)
+	UseEmailVerification(ctx context.Context, tokenHash string) (EmailVerification, error)
+	UseExchangeQuote(ctx context.Context, id uuid.UUID) (ExchangeQuote, error)
+	UsePasswordResetToken(ctx context.Context, tokenHash string) (PasswordResetToken, error)
+	UseSession(ctx context.Context, id uuid.UUID) (Session, error)
+	VerifyUserEmail(ctx context.Context, arg VerifyUserEmailParams) (User, error)
 }
 
 var _ Querier = (*Queries)(nil)
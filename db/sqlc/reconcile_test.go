@@ -0,0 +1,122 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/haniifac/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListAccountEntryTotals(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createAccountWithCurrency(t, util.USD)
+	account2 := createAccountWithCurrency(t, util.USD)
+
+	_, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+
+	rows, err := testQueries.ListAccountEntryTotals(context.Background(), ListAccountEntryTotalsParams{
+		AfterID: account1.ID - 1,
+		Limit:   1,
+	})
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	require.Equal(t, account1.ID, rows[0].ID)
+	require.Equal(t, account1.Balance-10, rows[0].Balance)
+	require.Equal(t, int64(-10), rows[0].EntriesTotal)
+}
+
+func TestListTransferEntryTotals(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createAccountWithCurrency(t, util.USD)
+	account2 := createAccountWithCurrency(t, util.USD)
+
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+
+	rows, err := testQueries.ListTransferEntryTotals(context.Background(), ListTransferEntryTotalsParams{
+		AfterID: result.Transfer.ID - 1,
+		Limit:   1,
+	})
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+
+	row := rows[0]
+	require.Equal(t, result.Transfer.ID, row.ID)
+	require.True(t, row.JournalID.Valid)
+	require.Equal(t, int64(1), row.Debits)
+	require.Equal(t, int64(-10), row.DebitTotal)
+	require.Equal(t, int64(1), row.Credits)
+	require.Equal(t, int64(10), row.CreditTotal)
+	require.Equal(t, int64(2), row.Entries)
+
+	journal, err := testQueries.GetJournal(context.Background(), row.JournalID.Int64)
+	require.NoError(t, err)
+	require.Equal(t, result.Transfer.ID, journal.TransferID.Int64)
+}
+
+func TestListJournalCurrencyTotals(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createAccountWithCurrency(t, util.USD)
+	account2 := createAccountWithCurrency(t, util.USD)
+
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+
+	transfers, err := testQueries.ListTransferEntryTotals(context.Background(), ListTransferEntryTotalsParams{
+		AfterID: result.Transfer.ID - 1,
+		Limit:   1,
+	})
+	require.NoError(t, err)
+	require.Len(t, transfers, 1)
+	journalID := transfers[0].JournalID.Int64
+
+	// the entries of a transfer in a single currency cancel out
+	rows, err := testQueries.ListJournalCurrencyTotals(context.Background(), ListJournalCurrencyTotalsParams{
+		AfterID: journalID - 1,
+		Limit:   1,
+	})
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	require.Equal(t, journalID, rows[0].JournalID)
+	require.Equal(t, util.USD, rows[0].Currency.String)
+	require.False(t, rows[0].Exchange)
+	require.Zero(t, rows[0].Total)
+}
+
+func TestReconciliationReport(t *testing.T) {
+	started := time.Now().Add(-time.Second)
+
+	report, err := testQueries.CreateReconciliationReport(context.Background(), CreateReconciliationReportParams{
+		StartedAt:  started,
+		FinishedAt: time.Now(),
+		Ok:         true,
+		Report:     json.RawMessage(`{"ok": true}`),
+	})
+	require.NoError(t, err)
+
+	latest, err := testQueries.GetLatestReconciliationReport(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, report.ID, latest.ID)
+	require.True(t, latest.Ok)
+	require.JSONEq(t, `{"ok": true}`, string(latest.Report))
+	require.WithinDuration(t, started, latest.StartedAt, time.Second)
+}
@@ -0,0 +1,92 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/haniifac/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomScheduledTransfer(t *testing.T, from, to Account, amount int64, scheduledAt time.Time) ScheduledTransfer {
+	arg := CreateScheduledTransferParams{
+		Owner:         from.Owner,
+		FromAccountID: from.ID,
+		ToAccountID:   to.ID,
+		Amount:        amount,
+		ScheduledAt:   scheduledAt,
+	}
+
+	scheduled, err := testQueries.CreateScheduledTransfer(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, scheduled.ID)
+	require.Equal(t, arg.Owner, scheduled.Owner)
+	require.Equal(t, arg.Amount, scheduled.Amount)
+	require.Equal(t, ScheduledTransferPending, scheduled.Status)
+	require.False(t, scheduled.TransferID.Valid)
+	require.WithinDuration(t, arg.ScheduledAt, scheduled.ScheduledAt, time.Second)
+
+	return scheduled
+}
+
+func TestCancelScheduledTransfer(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+	scheduled := createRandomScheduledTransfer(t, account1, account2, 10, time.Now().Add(time.Hour))
+
+	cancelled, err := testQueries.CancelScheduledTransfer(context.Background(), scheduled.ID)
+	require.NoError(t, err)
+	require.Equal(t, ScheduledTransferCancelled, cancelled.Status)
+
+	_, err = testQueries.CancelScheduledTransfer(context.Background(), scheduled.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestExecuteScheduledTransferTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createMemberAccount(t, util.USD)
+	account2 := createAccountWithCurrency(t, util.USD)
+	account3 := createAccountWithCurrency(t, util.EUR)
+
+	due := createRandomScheduledTransfer(t, account1, account2, 10, time.Now().Add(-time.Minute))
+	unfunded := createRandomScheduledTransfer(t, account1, account2, account1.Balance+1, time.Now().Add(-time.Minute))
+	otherCurrency := createRandomScheduledTransfer(t, account1, account3, 10, time.Now().Add(-time.Minute))
+
+	// the owner left the account after scheduling the transfer
+	left := createRandomScheduledTransfer(t, account1, account2, 10, time.Now().Add(-time.Minute))
+	left.Owner = createRandomUser(t).Username
+	_, err := testDB.Exec("UPDATE scheduled_transfers SET owner = $1 WHERE id = $2", left.Owner, left.ID)
+	require.NoError(t, err)
+
+	// other tests may leave due rows behind, so drain until all of ours have been processed
+	results := make(map[int64]ScheduledTransfer)
+	for {
+		result, err := store.ExecuteScheduledTransferTx(context.Background())
+		if err == sql.ErrNoRows {
+			break
+		}
+		require.NoError(t, err)
+		results[result.ScheduledTransfer.ID] = result.ScheduledTransfer
+	}
+
+	require.Equal(t, ScheduledTransferExecuted, results[due.ID].Status)
+	require.True(t, results[due.ID].TransferID.Valid)
+	require.True(t, results[due.ID].ExecutedAt.Valid)
+
+	require.Equal(t, ScheduledTransferFailed, results[unfunded.ID].Status)
+	require.Equal(t, ErrInsufficientFunds.Error(), results[unfunded.ID].FailureReason)
+	require.False(t, results[unfunded.ID].TransferID.Valid)
+
+	require.Equal(t, ScheduledTransferFailed, results[otherCurrency.ID].Status)
+	require.Contains(t, results[otherCurrency.ID].FailureReason, ErrCurrencyMismatch.Error())
+
+	require.Equal(t, ScheduledTransferFailed, results[left.ID].Status)
+	require.Contains(t, results[left.ID].FailureReason, ErrNotAllowedToSpend.Error())
+
+	updatedAccount1, err := testQueries.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance-10, updatedAccount1.Balance)
+}
@@ -12,6 +12,26 @@ import (
 	"github.com/google/uuid"
 )
 
+const blockSessionFamily = `-- name: BlockSessionFamily :exec
+UPDATE sessions SET is_blocked = true
+WHERE family_id = $1
+`
+
+func (q *Queries) BlockSessionFamily(ctx context.Context, familyID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, blockSessionFamily, familyID)
+	return err
+}
+
+const blockUserSessions = `-- name: BlockUserSessions :exec
+UPDATE sessions SET is_blocked = true
+WHERE username = $1 AND NOT is_blocked
+`
+
+func (q *Queries) BlockUserSessions(ctx context.Context, username string) error {
+	_, err := q.db.ExecContext(ctx, blockUserSessions, username)
+	return err
+}
+
 const createSession = `-- name: CreateSession :one
 INSERT INTO sessions (
     id,
@@ -21,10 +41,11 @@ INSERT INTO sessions (
     ip_address,
     created_at,
     expires_at,
-    is_blocked
+    is_blocked,
+    family_id
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8
-) RETURNING id, username, refresh_token, user_agent, ip_address, created_at, expires_at, is_blocked
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+) RETURNING id, username, refresh_token, user_agent, ip_address, created_at, expires_at, is_blocked, family_id, used_at
 `
 
 type CreateSessionParams struct {
@@ -36,6 +57,7 @@ type CreateSessionParams struct {
 	CreatedAt    time.Time `json:"created_at"`
 	ExpiresAt    time.Time `json:"expires_at"`
 	IsBlocked    bool      `json:"is_blocked"`
+	FamilyID     uuid.UUID `json:"family_id"`
 }
 
 func (q *Queries) CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error) {
@@ -48,6 +70,7 @@ func (q *Queries) CreateSession(ctx context.Context, arg CreateSessionParams) (S
 		arg.CreatedAt,
 		arg.ExpiresAt,
 		arg.IsBlocked,
+		arg.FamilyID,
 	)
 	var i Session
 	err := row.Scan(
@@ -59,12 +82,40 @@ func (q *Queries) CreateSession(ctx context.Context, arg CreateSessionParams) (S
 		&i.CreatedAt,
 		&i.ExpiresAt,
 		&i.IsBlocked,
+		&i.FamilyID,
+		&i.UsedAt,
+	)
+	return i, err
+}
+
+const getAuthSession = `-- name: GetAuthSession :one
+SELECT s.id, s.username, s.is_blocked, u.password_updated_at
+FROM sessions s
+JOIN users u ON u.username = s.username
+WHERE s.id = $1 LIMIT 1
+`
+
+type GetAuthSessionRow struct {
+	ID                uuid.UUID `json:"id"`
+	Username          string    `json:"username"`
+	IsBlocked         bool      `json:"is_blocked"`
+	PasswordUpdatedAt time.Time `json:"password_updated_at"`
+}
+
+func (q *Queries) GetAuthSession(ctx context.Context, id uuid.UUID) (GetAuthSessionRow, error) {
+	row := q.db.QueryRowContext(ctx, getAuthSession, id)
+	var i GetAuthSessionRow
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.IsBlocked,
+		&i.PasswordUpdatedAt,
 	)
 	return i, err
 }
 
 const getSession = `-- name: GetSession :one
-SELECT id, username, refresh_token, user_agent, ip_address, created_at, expires_at, is_blocked FROM sessions WHERE id = $1 LIMIT 1
+SELECT id, username, refresh_token, user_agent, ip_address, created_at, expires_at, is_blocked, family_id, used_at FROM sessions WHERE id = $1 LIMIT 1
 `
 
 func (q *Queries) GetSession(ctx context.Context, id uuid.UUID) (Session, error) {
@@ -79,6 +130,72 @@ func (q *Queries) GetSession(ctx context.Context, id uuid.UUID) (Session, error)
 		&i.CreatedAt,
 		&i.ExpiresAt,
 		&i.IsBlocked,
+		&i.FamilyID,
+		&i.UsedAt,
+	)
+	return i, err
+}
+
+const listActiveSessions = `-- name: ListActiveSessions :many
+SELECT id, username, refresh_token, user_agent, ip_address, created_at, expires_at, is_blocked, family_id, used_at FROM sessions
+WHERE username = $1 AND NOT is_blocked AND used_at IS NULL AND expires_at > now()
+ORDER BY created_at DESC
+`
+
+func (q *Queries) ListActiveSessions(ctx context.Context, username string) ([]Session, error) {
+	rows, err := q.db.QueryContext(ctx, listActiveSessions, username)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Session{}
+	for rows.Next() {
+		var i Session
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.RefreshToken,
+			&i.UserAgent,
+			&i.IpAddress,
+			&i.CreatedAt,
+			&i.ExpiresAt,
+			&i.IsBlocked,
+			&i.FamilyID,
+			&i.UsedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const useSession = `-- name: UseSession :one
+UPDATE sessions SET used_at = now()
+WHERE id = $1 AND used_at IS NULL
+RETURNING id, username, refresh_token, user_agent, ip_address, created_at, expires_at, is_blocked, family_id, used_at
+`
+
+func (q *Queries) UseSession(ctx context.Context, id uuid.UUID) (Session, error) {
+	row := q.db.QueryRowContext(ctx, useSession, id)
+	var i Session
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.RefreshToken,
+		&i.UserAgent,
+		&i.IpAddress,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+		&i.IsBlocked,
+		&i.FamilyID,
+		&i.UsedAt,
 	)
 	return i, err
 }
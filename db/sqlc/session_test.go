@@ -0,0 +1,83 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/haniifac/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func newSessionParams(username string, familyID uuid.UUID) CreateSessionParams {
+	id := uuid.New()
+	if familyID == uuid.Nil {
+		familyID = id
+	}
+
+	return CreateSessionParams{
+		ID:           id,
+		Username:     username,
+		RefreshToken: util.RandomString(32),
+		UserAgent:    "test",
+		IpAddress:    "127.0.0.1",
+		CreatedAt:    time.Now(),
+		ExpiresAt:    time.Now().Add(time.Hour),
+		FamilyID:     familyID,
+	}
+}
+
+func TestRotateSessionTx(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+
+	session, err := testQueries.CreateSession(context.Background(), newSessionParams(user.Username, uuid.Nil))
+	require.NoError(t, err)
+	require.Equal(t, session.ID, session.FamilyID)
+
+	rotated, err := store.RotateSessionTx(context.Background(), RotateSessionTxParams{
+		Session:    session,
+		NewSession: newSessionParams(user.Username, uuid.Nil),
+	})
+	require.NoError(t, err)
+	require.Equal(t, session.FamilyID, rotated.FamilyID)
+	require.False(t, rotated.UsedAt.Valid)
+
+	used, err := testQueries.GetSession(context.Background(), session.ID)
+	require.NoError(t, err)
+	require.True(t, used.UsedAt.Valid)
+
+	// presenting the used refresh token again blocks the session that replaced it
+	_, err = store.RotateSessionTx(context.Background(), RotateSessionTxParams{
+		Session:    session,
+		NewSession: newSessionParams(user.Username, uuid.Nil),
+	})
+	require.ErrorIs(t, err, ErrRefreshTokenReused)
+
+	rotated, err = testQueries.GetSession(context.Background(), rotated.ID)
+	require.NoError(t, err)
+	require.True(t, rotated.IsBlocked)
+}
+
+func TestListActiveSessions(t *testing.T) {
+	user := createRandomUser(t)
+
+	session1, err := testQueries.CreateSession(context.Background(), newSessionParams(user.Username, uuid.Nil))
+	require.NoError(t, err)
+	session2, err := testQueries.CreateSession(context.Background(), newSessionParams(user.Username, uuid.Nil))
+	require.NoError(t, err)
+
+	require.NoError(t, testQueries.BlockSessionFamily(context.Background(), session1.FamilyID))
+
+	sessions, err := testQueries.ListActiveSessions(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	require.Equal(t, session2.ID, sessions[0].ID)
+
+	require.NoError(t, testQueries.BlockUserSessions(context.Background(), user.Username))
+
+	sessions, err = testQueries.ListActiveSessions(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Empty(t, sessions)
+}
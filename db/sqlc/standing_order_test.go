@@ -0,0 +1,108 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/haniifac/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomStandingOrder(t *testing.T, from, to Account, amount int64, policy string) StandingOrder {
+	arg := CreateStandingOrderParams{
+		Owner:                   from.Owner,
+		FromAccountID:           from.ID,
+		ToAccountID:             to.ID,
+		Amount:                  amount,
+		Frequency:               util.Weekly,
+		InsufficientFundsPolicy: policy,
+		NextRunAt:               time.Now().Add(-time.Minute),
+		RemainingCount:          sql.NullInt32{Int32: 2, Valid: true},
+	}
+
+	order, err := testQueries.CreateStandingOrder(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, order.ID)
+	require.Equal(t, StandingOrderActive, order.Status)
+	require.Equal(t, arg.RemainingCount, order.RemainingCount)
+
+	return order
+}
+
+// executeStandingOrder drains due standing orders until the given one has been executed
+func executeStandingOrder(t *testing.T, store Store, orderID int64, arg ExecuteStandingOrderTxParams) ExecuteStandingOrderTxResult {
+	for {
+		result, err := store.ExecuteStandingOrderTx(context.Background(), arg)
+		require.NoError(t, err)
+		if result.StandingOrder.ID == orderID {
+			return result
+		}
+	}
+}
+
+func TestExecuteStandingOrderTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createMemberAccount(t, util.USD)
+	account2 := createAccountWithCurrency(t, util.USD)
+	order := createRandomStandingOrder(t, account1, account2, 10, InsufficientFundsSkip)
+
+	result := executeStandingOrder(t, store, order.ID, ExecuteStandingOrderTxParams{})
+	require.Equal(t, StandingOrderExecutionSucceeded, result.Execution.Status)
+	require.True(t, result.Execution.TransferID.Valid)
+	require.Equal(t, int32(1), result.StandingOrder.RemainingCount.Int32)
+	require.Equal(t, StandingOrderActive, result.StandingOrder.Status)
+	require.WithinDuration(t, order.NextRunAt.AddDate(0, 0, 7), result.StandingOrder.NextRunAt, time.Second)
+}
+
+func TestExecuteStandingOrderTxInsufficientFunds(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createMemberAccount(t, util.USD)
+	account2 := createAccountWithCurrency(t, util.USD)
+
+	skipped := createRandomStandingOrder(t, account1, account2, account1.Balance+1, InsufficientFundsSkip)
+	result := executeStandingOrder(t, store, skipped.ID, ExecuteStandingOrderTxParams{RetryInterval: time.Hour})
+	require.Equal(t, StandingOrderExecutionSkipped, result.Execution.Status)
+	require.Equal(t, ErrInsufficientFunds.Error(), result.Execution.FailureReason)
+	require.False(t, result.StandingOrder.RetryAt.Valid)
+	require.Equal(t, int32(1), result.StandingOrder.RemainingCount.Int32)
+
+	retried := createRandomStandingOrder(t, account1, account2, account1.Balance+1, InsufficientFundsRetry)
+	result = executeStandingOrder(t, store, retried.ID, ExecuteStandingOrderTxParams{RetryInterval: time.Hour})
+	require.Equal(t, StandingOrderExecutionRetrying, result.Execution.Status)
+	require.True(t, result.StandingOrder.RetryAt.Valid)
+	require.WithinDuration(t, retried.NextRunAt, result.StandingOrder.NextRunAt, time.Second)
+	require.Equal(t, int32(2), result.StandingOrder.RemainingCount.Int32)
+}
+
+func TestExecuteStandingOrderTxCancelled(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createMemberAccount(t, util.USD)
+	account2 := createAccountWithCurrency(t, util.USD)
+	account3 := createAccountWithCurrency(t, util.EUR)
+
+	otherCurrency := createRandomStandingOrder(t, account1, account3, 10, InsufficientFundsRetry)
+	result := executeStandingOrder(t, store, otherCurrency.ID, ExecuteStandingOrderTxParams{RetryInterval: time.Hour})
+	require.Equal(t, StandingOrderExecutionSkipped, result.Execution.Status)
+	require.Contains(t, result.Execution.FailureReason, ErrCurrencyMismatch.Error())
+	require.Equal(t, StandingOrderCancelled, result.StandingOrder.Status)
+
+	// the owner left the account after setting up the order
+	left := createRandomStandingOrder(t, account1, account2, 10, InsufficientFundsRetry)
+	_, err := testDB.Exec("UPDATE standing_orders SET owner = $1 WHERE id = $2", createRandomUser(t).Username, left.ID)
+	require.NoError(t, err)
+
+	result = executeStandingOrder(t, store, left.ID, ExecuteStandingOrderTxParams{RetryInterval: time.Hour})
+	require.Equal(t, StandingOrderExecutionSkipped, result.Execution.Status)
+	require.Contains(t, result.Execution.FailureReason, ErrNotAllowedToSpend.Error())
+	require.Equal(t, StandingOrderCancelled, result.StandingOrder.Status)
+	require.False(t, result.Execution.TransferID.Valid)
+
+	updatedAccount1, err := testQueries.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance, updatedAccount1.Balance)
+}
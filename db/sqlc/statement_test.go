@@ -0,0 +1,62 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountStatementTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+
+	// entries created in the test transactions are stamped with the database clock
+	var startTime time.Time
+	require.NoError(t, testDB.QueryRow("SELECT now()").Scan(&startTime))
+
+	amounts := []int64{10, 25, 5}
+	for _, amount := range amounts {
+		_, err := store.TransferTx(context.Background(), TransferTxParams{
+			FromAccountID: account1.ID,
+			ToAccountID:   account2.ID,
+			Amount:        amount,
+		})
+		require.NoError(t, err)
+	}
+
+	result, err := store.AccountStatementTx(context.Background(), AccountStatementTxParams{
+		AccountID: account1.ID,
+		StartTime: startTime,
+		EndTime:   time.Now().Add(time.Minute),
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, account1.Balance, result.OpeningBalance)
+	require.Len(t, result.Lines, len(amounts))
+
+	balance := result.OpeningBalance
+	for i, line := range result.Lines {
+		balance -= amounts[i]
+		require.Equal(t, -amounts[i], line.Amount)
+		require.Equal(t, balance, line.Balance)
+		require.NotEmpty(t, line.Description)
+	}
+
+	require.Equal(t, balance, result.ClosingBalance)
+	require.Equal(t, balance, result.Account.Balance)
+
+	// a range without entries still reports the balance at that time
+	empty, err := store.AccountStatementTx(context.Background(), AccountStatementTxParams{
+		AccountID: account1.ID,
+		StartTime: startTime.Add(-time.Hour),
+		EndTime:   startTime.Add(-time.Minute),
+	})
+	require.NoError(t, err)
+	require.Empty(t, empty.Lines)
+	require.Equal(t, account1.Balance, empty.OpeningBalance)
+	require.Equal(t, account1.Balance, empty.ClosingBalance)
+}
@@ -3,29 +3,120 @@ package db
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
+	"slices"
+	"time"
+)
+
+// account statuses
+const (
+	AccountActive = "active"
+	AccountFrozen = "frozen"
+	AccountClosed = "closed"
+)
+
+// account kinds
+const (
+	AccountChecking = "checking"
+	AccountSavings  = "savings"
+)
+
+// purposes of bank owned accounts
+const (
+	BankInterestExpense = "interest_expense"
+	BankFeeRevenue      = "fee_revenue"
+)
+
+var (
+	ErrNoBankAccount          = errors.New("bank account is not configured")
+	ErrInsufficientFunds      = errors.New("insufficient funds")
+	ErrAccountNotActive       = errors.New("account is not active")
+	ErrCurrencyMismatch       = errors.New("accounts do not share a currency")
+	ErrIdempotencyKeyMismatch = errors.New("idempotency key was already used with a different request")
+	ErrIdempotencyKeyInUse    = errors.New("idempotency key is being used by a concurrent request")
 )
 
 type Store interface {
+	CreateAccountTx(ctx context.Context, arg CreateAccountParams) (Account, error)
 	TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error)
+	ReverseTransferTx(ctx context.Context, arg ReverseTransferTxParams) (TransferTxResult, error)
+	ExecuteScheduledTransferTx(ctx context.Context) (ExecuteScheduledTransferTxResult, error)
+	ExecuteStandingOrderTx(ctx context.Context, arg ExecuteStandingOrderTxParams) (ExecuteStandingOrderTxResult, error)
+	ExchangeTransferTx(ctx context.Context, arg ExchangeTransferTxParams) (TransferTxResult, error)
+	PostJournalTx(ctx context.Context, arg PostJournalTxParams) (PostJournalTxResult, error)
+	AccountStatementTx(ctx context.Context, arg AccountStatementTxParams) (AccountStatementTxResult, error)
+	StreamAccountStatementTx(ctx context.Context, arg AccountStatementTxParams, w StatementWriter) error
+	CreateHoldTx(ctx context.Context, arg CreateHoldTxParams) (Hold, error)
+	CaptureHoldTx(ctx context.Context, arg CaptureHoldTxParams) (CaptureHoldTxResult, error)
+	AccrueInterestTx(ctx context.Context, arg AccrueInterestTxParams) (InterestAccrual, error)
+	PostInterestTx(ctx context.Context, arg PostInterestTxParams) (PostInterestTxResult, error)
+	ChargePeriodicFeesTx(ctx context.Context, arg ChargePeriodicFeesTxParams) ([]FeeCharge, error)
+	BalanceAsOfTx(ctx context.Context, arg BalanceAsOfTxParams) (BalanceAsOfTxResult, error)
+	AccountLimitsTx(ctx context.Context, accountID int64) (AccountLimits, error)
+	RotateSessionTx(ctx context.Context, arg RotateSessionTxParams) (Session, error)
+	ChangePasswordTx(ctx context.Context, arg ChangePasswordTxParams) (User, error)
+	ResetPasswordTx(ctx context.Context, arg ResetPasswordTxParams) (User, error)
+	VerifyEmailTx(ctx context.Context, tokenHash string) (User, error)
 	Querier
 }
 
 // Store provides all functions to execute SQL queries and transactions
 type SQLStore struct {
 	*Queries
-	db *sql.DB
+	db      *sql.DB
+	options TxOptions
 }
 
 func NewStore(db *sql.DB) Store {
+	return NewStoreWithOptions(db, DefaultTxOptions())
+}
+
+// NewStoreWithOptions creates a store that runs its transactions with the given options
+func NewStoreWithOptions(db *sql.DB, options TxOptions) Store {
 	return &SQLStore{
 		db:      db,
 		Queries: New(db),
+		options: options,
 	}
 }
 
-func (store *SQLStore) execTx(ctx context.Context, fn func(*Queries) error) error {
-	tx, err := store.db.BeginTx(ctx, nil)
+// execTx runs fn in a transaction with the given isolation level. When the transaction fails with a deadlock
+// or serialization failure it is rolled back and fn runs again after a jittered backoff, up to the configured
+// number of attempts, so fn must not keep state between calls other than its result.
+func (store *SQLStore) execTx(ctx context.Context, isolation sql.IsolationLevel, fn func(*Queries) error) error {
+	for attempt := 1; ; attempt++ {
+		err := store.runTx(ctx, isolation, fn)
+		code, retryable := retryableTxError(err)
+		if !retryable {
+			if attempt > 1 && err == nil {
+				txRetriedSucceeded.Add(1)
+			}
+			return err
+		}
+
+		txRetries.Add(code, 1)
+		if attempt >= store.options.MaxAttempts {
+			txRetriesExhausted.Add(1)
+			log.Printf("tx failed with %s after %d attempts: %v", code, attempt, err)
+			return err
+		}
+
+		delay := store.options.backoff(attempt)
+		log.Printf("tx attempt %d failed with %s, retrying in %s", attempt, code, delay)
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+	}
+}
+
+func (store *SQLStore) runTx(ctx context.Context, isolation sql.IsolationLevel, fn func(*Queries) error) error {
+	tx, err := store.db.BeginTx(ctx, &sql.TxOptions{Isolation: isolation})
 	if err != nil {
 		return err
 	}
@@ -34,7 +125,7 @@ func (store *SQLStore) execTx(ctx context.Context, fn func(*Queries) error) erro
 	err = fn(q)
 	if err != nil {
 		if rollbackErr := tx.Rollback(); rollbackErr != nil {
-			return fmt.Errorf("tx err: %v, rb err: %v", err, rollbackErr)
+			return fmt.Errorf("tx err: %w, rb err: %v", err, rollbackErr)
 		}
 		return err
 	}
@@ -43,9 +134,18 @@ func (store *SQLStore) execTx(ctx context.Context, fn func(*Queries) error) erro
 }
 
 type TransferTxParams struct {
-	FromAccountID int64 `json:"from_account_id"`
-	ToAccountID   int64 `json:"to_account_id"`
-	Amount        int64 `json:"amount"`
+	FromAccountID  int64                 `json:"from_account_id"`
+	ToAccountID    int64                 `json:"to_account_id"`
+	Amount         int64                 `json:"amount"`
+	IdempotencyKey *IdempotencyKeyParams `json:"-"`
+}
+
+// IdempotencyKeyParams identifies a client supplied key that makes a transfer safe to retry
+type IdempotencyKeyParams struct {
+	Username    string
+	Key         string
+	RequestHash string
+	ExpiresAt   time.Time
 }
 
 type TransferTxResult struct {
@@ -54,74 +154,240 @@ type TransferTxResult struct {
 	ToAccount   Account  `json:"to_account"`
 	ToEntry     Entry    `json:"to_entry"`
 	FromEntry   Entry    `json:"from_entry"`
+	// Fee is the transfer fee charged to the sending account, if any
+	Fee *FeeCharge `json:"fee,omitempty"`
 }
 
 // TransferTx performs money transfer from one account to another within a single transaction operation
 // Steps: 1) create transfer record, 2) add account entries, 3) Update each account's balance
+// When an idempotency key is given, a replay of the same request returns the stored result instead of moving money again
 func (store *SQLStore) TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error) {
 	var result TransferTxResult
 
-	err := store.execTx(ctx, func(q *Queries) error {
+	err := store.execTx(ctx, store.transferIsolation(ctx), func(q *Queries) error {
 		var err error
 
-		// Check for overdraft balance
-		fromAccount, _, err := lockTwoAccounts(ctx, q, arg.FromAccountID, arg.ToAccountID)
-
-		// fromAccount, err := q.GetAccountForUpdate(ctx, arg.FromAccountID)
-		if err != nil {
-			return err
-		}
-		if fromAccount.Balance < arg.Amount {
-			return fmt.Errorf("insufficient funds")
+		if arg.IdempotencyKey != nil {
+			replayed, err := replayIdempotencyKey(ctx, q, arg.IdempotencyKey, &result)
+			if err != nil || replayed {
+				return err
+			}
 		}
 
-		// Step 1)
-		result.Transfer, err = q.CreateTransfer(ctx, CreateTransferParams{
+		result, err = transferMoney(ctx, q, CreateTransferParams{
 			FromAccountID: arg.FromAccountID,
 			ToAccountID:   arg.ToAccountID,
 			Amount:        arg.Amount,
-		})
+		}, &store.options.TransferLimits)
 		if err != nil {
 			return err
 		}
 
-		// Step 2)
-		result.FromEntry, err = q.CreateEntry(ctx, CreateEntryParams{
-			AccountID: arg.FromAccountID,
-			Amount:    -arg.Amount,
-		})
-		if err != nil {
-			return err
+		if arg.IdempotencyKey != nil {
+			return saveIdempotencyKey(ctx, q, arg.IdempotencyKey, result)
 		}
 
-		result.ToEntry, err = q.CreateEntry(ctx, CreateEntryParams{
-			AccountID: arg.ToAccountID,
-			Amount:    arg.Amount,
-		})
+		return nil
+	})
+
+	return result, err
+}
+
+// transferMoney books a transfer record, its two entries and the balance updates using the given queries.
+// The outgoing limits and the transfer fee of the sending account apply unless limits is nil, which reversals use
+// because they return money the sending account received. Every check happens before anything is written,
+// so callers can record a failed transfer in the same transaction.
+func transferMoney(ctx context.Context, q *Queries, arg CreateTransferParams, limits *TransferLimits) (TransferTxResult, error) {
+	var result TransferTxResult
+
+	// the fee revenue account is locked together with both accounts, so every row is locked in ascending id order
+	lockIDs := []int64{arg.FromAccountID, arg.ToAccountID}
+
+	var feeSchedule *FeeSchedule
+	if limits != nil {
+		fromAccount, err := q.GetAccount(ctx, arg.FromAccountID)
 		if err != nil {
-			return err
+			return result, err
 		}
 
-		// always start AddAccountBalance in the same order (smaller id first) to avoid exclusive lock deadlock
-		// if account1 row is locked and account2 row is locked from two transfers or more, deadlock will occur.
-		if arg.FromAccountID < arg.ToAccountID {
-			result.FromAccount, result.ToAccount, err = addMoney(q, arg.FromAccountID, -arg.Amount, arg.ToAccountID, arg.Amount)
-			if err != nil {
-				return err
-			}
-		} else {
-			result.ToAccount, result.FromAccount, err = addMoney(q, arg.ToAccountID, arg.Amount, arg.FromAccountID, -arg.Amount)
+		feeSchedule, err = transferFeeSchedule(ctx, q, fromAccount)
+		if err != nil {
+			return result, err
+		}
+		if feeSchedule != nil {
+			revenue, err := bankAccount(ctx, q, BankFeeRevenue, fromAccount.Currency)
 			if err != nil {
-				return err
+				return result, err
 			}
+			lockIDs = append(lockIDs, revenue.AccountID)
 		}
+	}
 
-		return nil
+	accounts, err := lockAccounts(ctx, q, lockIDs...)
+	if err != nil {
+		return result, err
+	}
+	for _, id := range []int64{arg.FromAccountID, arg.ToAccountID} {
+		if account := accounts[id]; account.Status != AccountActive {
+			return result, fmt.Errorf("%w: account %d is %s", ErrAccountNotActive, account.ID, account.Status)
+		}
+	}
+
+	if limits != nil {
+		if err := checkTransferLimits(ctx, q, accounts[arg.FromAccountID], arg.Amount, *limits); err != nil {
+			return result, err
+		}
+	}
+
+	fee := int64(0)
+	if feeSchedule != nil {
+		fee = feeSchedule.TransferFee
+	}
+
+	// Check for overdraft balance, funds reserved by holds are not available for transfers and the fee has to be covered too
+	held, err := q.GetHeldAmount(ctx, arg.FromAccountID)
+	if err != nil {
+		return result, err
+	}
+	if AvailableBalance(accounts[arg.FromAccountID], held) < arg.Amount+fee {
+		return result, ErrInsufficientFunds
+	}
+
+	// Step 1)
+	result.Transfer, err = q.CreateTransfer(ctx, arg)
+	if err != nil {
+		return result, err
+	}
+
+	journal, err := q.CreateTransferJournal(ctx, CreateTransferJournalParams{
+		Description: fmt.Sprintf("transfer %d", result.Transfer.ID),
+		TransferID:  sql.NullInt64{Int64: result.Transfer.ID, Valid: true},
 	})
+	if err != nil {
+		return result, err
+	}
+	journalID := sql.NullInt64{Int64: journal.ID, Valid: true}
+
+	// the destination is credited in its own currency for cross currency transfers. Their journals are the one kind
+	// that does not sum to zero per currency, as no bank account books the other side of the exchange, which the
+	// reconciliation allows for.
+	credit := arg.Amount
+	if arg.ToAmount.Valid {
+		credit = arg.ToAmount.Int64
+	}
+
+	// Step 2)
+	result.FromEntry, err = q.CreateEntry(ctx, CreateEntryParams{
+		AccountID: arg.FromAccountID,
+		Amount:    -arg.Amount,
+		JournalID: journalID,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	result.ToEntry, err = q.CreateEntry(ctx, CreateEntryParams{
+		AccountID: arg.ToAccountID,
+		Amount:    credit,
+		JournalID: journalID,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	// always start AddAccountBalance in the same order (smaller id first) to avoid exclusive lock deadlock
+	// if account1 row is locked and account2 row is locked from two transfers or more, deadlock will occur.
+	if arg.FromAccountID < arg.ToAccountID {
+		result.FromAccount, result.ToAccount, err = addMoney(q, arg.FromAccountID, -arg.Amount, arg.ToAccountID, credit)
+	} else {
+		result.ToAccount, result.FromAccount, err = addMoney(q, arg.ToAccountID, credit, arg.FromAccountID, -arg.Amount)
+	}
+	if err != nil {
+		return result, err
+	}
+
+	result.Fee, err = chargeTransferFee(ctx, q, result.FromAccount, feeSchedule, result.Transfer)
+	if err != nil {
+		return result, err
+	}
+	if result.Fee != nil {
+		result.FromAccount, err = q.GetAccount(ctx, arg.FromAccountID)
+	}
 
 	return result, err
 }
 
+// transferOnBehalf makes a transfer a user set up earlier, such as a scheduled transfer or a standing order,
+// the way TransferTx would make it now. The user must still be allowed to spend from the sending account and
+// both accounts must still share a currency, which the API only checked when the transfer was set up.
+func transferOnBehalf(ctx context.Context, q *Queries, username string, arg CreateTransferParams, limits *TransferLimits) (TransferTxResult, error) {
+	if err := checkSpender(ctx, q, arg.FromAccountID, username); err != nil {
+		return TransferTxResult{}, err
+	}
+
+	fromAccount, err := q.GetAccount(ctx, arg.FromAccountID)
+	if err != nil {
+		return TransferTxResult{}, err
+	}
+	toAccount, err := q.GetAccount(ctx, arg.ToAccountID)
+	if err != nil {
+		return TransferTxResult{}, err
+	}
+	if fromAccount.Currency != toAccount.Currency {
+		return TransferTxResult{}, fmt.Errorf("%w: account %d is in %s, account %d in %s",
+			ErrCurrencyMismatch, fromAccount.ID, fromAccount.Currency, toAccount.ID, toAccount.Currency)
+	}
+
+	return transferMoney(ctx, q, arg, limits)
+}
+
+// replayIdempotencyKey loads the result stored for an unexpired key into result.
+// It reports false when the key has not been used yet.
+func replayIdempotencyKey(ctx context.Context, q *Queries, key *IdempotencyKeyParams, result *TransferTxResult) (bool, error) {
+	stored, err := q.GetIdempotencyKey(ctx, GetIdempotencyKeyParams{
+		Username: key.Username,
+		Key:      key.Key,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	if stored.RequestHash != key.RequestHash {
+		return false, ErrIdempotencyKeyMismatch
+	}
+
+	if err := json.Unmarshal(stored.Response, result); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// saveIdempotencyKey stores the transfer result under the key. If another transaction claimed the
+// same key in the meantime no row is returned and the whole transfer is rolled back.
+func saveIdempotencyKey(ctx context.Context, q *Queries, key *IdempotencyKeyParams, result TransferTxResult) error {
+	response, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+
+	_, err = q.CreateIdempotencyKey(ctx, CreateIdempotencyKeyParams{
+		Username:    key.Username,
+		Key:         key.Key,
+		RequestHash: key.RequestHash,
+		TransferID:  result.Transfer.ID,
+		Response:    response,
+		ExpiresAt:   key.ExpiresAt,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrIdempotencyKeyInUse
+	}
+	return err
+}
+
 func addMoney(q *Queries, accountId1 int64, amount1 int64, accountId2 int64, amount2 int64) (Account, Account, error) {
 	acc1, err := q.AddAccountBalance(context.Background(), AddAccountBalanceParams{
 		ID:     accountId1,
@@ -142,26 +408,47 @@ func addMoney(q *Queries, accountId1 int64, amount1 int64, accountId2 int64, amo
 	return acc1, acc2, nil
 }
 
-func lockTwoAccounts(ctx context.Context, q *Queries, id1, id2 int64) (Account, Account, error) {
-	if id1 < id2 {
-		acc1, err := q.GetAccountForUpdate(ctx, id1)
-		if err != nil {
-			return Account{}, Account{}, err
-		}
-		acc2, err := q.GetAccountForUpdate(ctx, id2)
+// lockAccounts locks the given accounts for update in ascending id order, so concurrent transactions
+// touching an overlapping set of accounts always wait on each other instead of deadlocking
+func lockAccounts(ctx context.Context, q *Queries, ids ...int64) (map[int64]Account, error) {
+	sorted := make([]int64, len(ids))
+	copy(sorted, ids)
+	slices.Sort(sorted)
+	sorted = slices.Compact(sorted)
+
+	accounts := make(map[int64]Account, len(sorted))
+	for _, id := range sorted {
+		account, err := q.GetAccountForUpdate(ctx, id)
 		if err != nil {
-			return Account{}, Account{}, err
+			return nil, err
 		}
-		return acc1, acc2, nil
+		accounts[id] = account
 	}
 
-	acc2, err := q.GetAccountForUpdate(ctx, id2)
-	if err != nil {
-		return Account{}, Account{}, err
+	return accounts, nil
+}
+
+// bankAccount returns the bank owned account for a purpose in the given currency
+func bankAccount(ctx context.Context, q *Queries, purpose string, currency string) (BankAccount, error) {
+	account, err := q.GetBankAccount(ctx, GetBankAccountParams{
+		Purpose:  purpose,
+		Currency: currency,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return account, fmt.Errorf("%w: %s in %s", ErrNoBankAccount, purpose, currency)
 	}
-	acc1, err := q.GetAccountForUpdate(ctx, id1)
-	if err != nil {
-		return Account{}, Account{}, err
+	return account, err
+}
+
+// OverdraftInUse is how far a balance is below zero
+func OverdraftInUse(balance int64) int64 {
+	if balance < 0 {
+		return -balance
 	}
-	return acc1, acc2, nil
+	return 0
+}
+
+// AvailableBalance is what an account can still spend: its balance and overdraft limit minus the held funds
+func AvailableBalance(account Account, held int64) int64 {
+	return account.Balance + account.OverdraftLimit - held
 }
@@ -7,26 +7,41 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"time"
 )
 
 const createTransfer = `-- name: CreateTransfer :one
 INSERT INTO transfers (
     from_account_id,
     to_account_id,
-    amount
+    amount,
+    reversed_transfer_id,
+    to_amount,
+    exchange_rate
 ) VALUES (
-    $1, $2, $3
-) RETURNING id, from_account_id, to_account_id, amount, created_at
+    $1, $2, $3, $4, $5, $6
+) RETURNING id, from_account_id, to_account_id, amount, created_at, reversed_transfer_id, to_amount, exchange_rate
 `
 
 type CreateTransferParams struct {
-	FromAccountID int64 `json:"from_account_id"`
-	ToAccountID   int64 `json:"to_account_id"`
-	Amount        int64 `json:"amount"`
+	FromAccountID      int64          `json:"from_account_id"`
+	ToAccountID        int64          `json:"to_account_id"`
+	Amount             int64          `json:"amount"`
+	ReversedTransferID sql.NullInt64  `json:"reversed_transfer_id"`
+	ToAmount           sql.NullInt64  `json:"to_amount"`
+	ExchangeRate       sql.NullString `json:"exchange_rate"`
 }
 
 func (q *Queries) CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error) {
-	row := q.db.QueryRowContext(ctx, createTransfer, arg.FromAccountID, arg.ToAccountID, arg.Amount)
+	row := q.db.QueryRowContext(ctx, createTransfer,
+		arg.FromAccountID,
+		arg.ToAccountID,
+		arg.Amount,
+		arg.ReversedTransferID,
+		arg.ToAmount,
+		arg.ExchangeRate,
+	)
 	var i Transfer
 	err := row.Scan(
 		&i.ID,
@@ -34,12 +49,35 @@ func (q *Queries) CreateTransfer(ctx context.Context, arg CreateTransferParams)
 		&i.ToAccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.ReversedTransferID,
+		&i.ToAmount,
+		&i.ExchangeRate,
 	)
 	return i, err
 }
 
+const getReversedAmount = `-- name: GetReversedAmount :one
+SELECT COALESCE(SUM(COALESCE(to_amount, amount)), 0)::bigint AS reversed_amount,
+    COALESCE(SUM(amount), 0)::bigint AS reversed_to_amount
+FROM transfers
+WHERE reversed_transfer_id = $1::bigint
+`
+
+type GetReversedAmountRow struct {
+	ReversedAmount   int64 `json:"reversed_amount"`
+	ReversedToAmount int64 `json:"reversed_to_amount"`
+}
+
+// reversed_amount is what was returned to the sender of the transfer, reversed_to_amount what was taken back from its receiver
+func (q *Queries) GetReversedAmount(ctx context.Context, transferID int64) (GetReversedAmountRow, error) {
+	row := q.db.QueryRowContext(ctx, getReversedAmount, transferID)
+	var i GetReversedAmountRow
+	err := row.Scan(&i.ReversedAmount, &i.ReversedToAmount)
+	return i, err
+}
+
 const getTransfer = `-- name: GetTransfer :one
-SELECT id, from_account_id, to_account_id, amount, created_at FROM transfers WHERE id = $1 LIMIT 1
+SELECT id, from_account_id, to_account_id, amount, created_at, reversed_transfer_id, to_amount, exchange_rate FROM transfers WHERE id = $1 LIMIT 1
 `
 
 func (q *Queries) GetTransfer(ctx context.Context, id int64) (Transfer, error) {
@@ -51,12 +89,102 @@ func (q *Queries) GetTransfer(ctx context.Context, id int64) (Transfer, error) {
 		&i.ToAccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.ReversedTransferID,
+		&i.ToAmount,
+		&i.ExchangeRate,
+	)
+	return i, err
+}
+
+const getTransferForUpdate = `-- name: GetTransferForUpdate :one
+SELECT id, from_account_id, to_account_id, amount, created_at, reversed_transfer_id, to_amount, exchange_rate FROM transfers WHERE id = $1 LIMIT 1 FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetTransferForUpdate(ctx context.Context, id int64) (Transfer, error) {
+	row := q.db.QueryRowContext(ctx, getTransferForUpdate, id)
+	var i Transfer
+	err := row.Scan(
+		&i.ID,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.CreatedAt,
+		&i.ReversedTransferID,
+		&i.ToAmount,
+		&i.ExchangeRate,
 	)
 	return i, err
 }
 
+const listAccountTransfers = `-- name: ListAccountTransfers :many
+SELECT id, from_account_id, to_account_id, amount, created_at, reversed_transfer_id, to_amount, exchange_rate FROM transfers
+WHERE (from_account_id = $1 OR to_account_id = $1)
+    AND ($2::text IS NULL
+        OR ($2::text = 'out' AND from_account_id = $1)
+        OR ($2::text = 'in' AND to_account_id = $1))
+    AND ($3::timestamptz IS NULL OR created_at >= $3::timestamptz)
+    AND ($4::timestamptz IS NULL OR created_at < $4::timestamptz)
+    AND ($5::bigint IS NULL OR amount >= $5::bigint)
+    AND ($6::bigint IS NULL OR amount <= $6::bigint)
+    AND ($7::bigint IS NULL OR id < $7::bigint)
+ORDER BY id DESC
+LIMIT $8
+`
+
+type ListAccountTransfersParams struct {
+	AccountID int64          `json:"account_id"`
+	Direction sql.NullString `json:"direction"`
+	StartTime sql.NullTime   `json:"start_time"`
+	EndTime   sql.NullTime   `json:"end_time"`
+	MinAmount sql.NullInt64  `json:"min_amount"`
+	MaxAmount sql.NullInt64  `json:"max_amount"`
+	BeforeID  sql.NullInt64  `json:"before_id"`
+	PageSize  int32          `json:"page_size"`
+}
+
+func (q *Queries) ListAccountTransfers(ctx context.Context, arg ListAccountTransfersParams) ([]Transfer, error) {
+	rows, err := q.db.QueryContext(ctx, listAccountTransfers,
+		arg.AccountID,
+		arg.Direction,
+		arg.StartTime,
+		arg.EndTime,
+		arg.MinAmount,
+		arg.MaxAmount,
+		arg.BeforeID,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Transfer{}
+	for rows.Next() {
+		var i Transfer
+		if err := rows.Scan(
+			&i.ID,
+			&i.FromAccountID,
+			&i.ToAccountID,
+			&i.Amount,
+			&i.CreatedAt,
+			&i.ReversedTransferID,
+			&i.ToAmount,
+			&i.ExchangeRate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listTransfers = `-- name: ListTransfers :many
-SELECT id, from_account_id, to_account_id, amount, created_at FROM transfers ORDER BY id LIMIT $1 OFFSET $2
+SELECT id, from_account_id, to_account_id, amount, created_at, reversed_transfer_id, to_amount, exchange_rate FROM transfers ORDER BY id LIMIT $1 OFFSET $2
 `
 
 type ListTransfersParams struct {
@@ -79,6 +207,9 @@ func (q *Queries) ListTransfers(ctx context.Context, arg ListTransfersParams) ([
 			&i.ToAccountID,
 			&i.Amount,
 			&i.CreatedAt,
+			&i.ReversedTransferID,
+			&i.ToAmount,
+			&i.ExchangeRate,
 		); err != nil {
 			return nil, err
 		}
@@ -94,7 +225,7 @@ func (q *Queries) ListTransfers(ctx context.Context, arg ListTransfersParams) ([
 }
 
 const listTransfersByAccountId = `-- name: ListTransfersByAccountId :many
-SELECT id, from_account_id, to_account_id, amount, created_at FROM transfers WHERE from_account_id = $1 OR to_account_id = $2 ORDER BY id LIMIT $3 OFFSET $4
+SELECT id, from_account_id, to_account_id, amount, created_at, reversed_transfer_id, to_amount, exchange_rate FROM transfers WHERE from_account_id = $1 OR to_account_id = $2 ORDER BY id LIMIT $3 OFFSET $4
 `
 
 type ListTransfersByAccountIdParams struct {
@@ -124,6 +255,9 @@ func (q *Queries) ListTransfersByAccountId(ctx context.Context, arg ListTransfer
 			&i.ToAccountID,
 			&i.Amount,
 			&i.CreatedAt,
+			&i.ReversedTransferID,
+			&i.ToAmount,
+			&i.ExchangeRate,
 		); err != nil {
 			return nil, err
 		}
@@ -137,3 +271,34 @@ func (q *Queries) ListTransfersByAccountId(ctx context.Context, arg ListTransfer
 	}
 	return items, nil
 }
+
+const getOutgoingTransferTotals = `-- name: GetOutgoingTransferTotals :one
+SELECT
+    (COALESCE(SUM(amount) FILTER (WHERE created_at >= $1), 0))::bigint AS day_total,
+    (COALESCE(SUM(amount), 0))::bigint AS month_total
+FROM transfers
+WHERE from_account_id = $2
+    AND created_at >= $3
+    AND reversed_transfer_id IS NULL
+`
+
+type GetOutgoingTransferTotalsParams struct {
+	DayStart   time.Time `json:"day_start"`
+	AccountID  int64     `json:"account_id"`
+	MonthStart time.Time `json:"month_start"`
+}
+
+type GetOutgoingTransferTotalsRow struct {
+	DayTotal   int64 `json:"day_total"`
+	MonthTotal int64 `json:"month_total"`
+}
+
+func (q *Queries) GetOutgoingTransferTotals(ctx context.Context, arg GetOutgoingTransferTotalsParams) (GetOutgoingTransferTotalsRow, error) {
+	row := q.db.QueryRowContext(ctx, getOutgoingTransferTotals, arg.DayStart, arg.AccountID, arg.MonthStart)
+	var i GetOutgoingTransferTotalsRow
+	err := row.Scan(
+		&i.DayTotal,
+		&i.MonthTotal,
+	)
+	return i, err
+}
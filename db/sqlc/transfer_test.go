@@ -2,7 +2,9 @@ package db
 
 import (
 	"context"
+	"database/sql"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -105,3 +107,61 @@ func TestListTransfersByAccountId(t *testing.T) {
 	require.NoError(t, err)
 	require.Len(t, transfers, int(argList.Limit))
 }
+
+func TestListAccountTransfers(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+
+	var created []Transfer
+	for i := 1; i <= 6; i++ {
+		arg := CreateTransferParams{
+			FromAccountID: account1.ID,
+			ToAccountID:   account2.ID,
+			Amount:        int64(i * 10),
+		}
+		if i%2 == 0 {
+			arg.FromAccountID, arg.ToAccountID = account2.ID, account1.ID
+		}
+
+		transfer, err := testQueries.CreateTransfer(context.Background(), arg)
+		require.NoError(t, err)
+		created = append(created, transfer)
+	}
+
+	// newest first with keyset pagination
+	page1, err := testQueries.ListAccountTransfers(context.Background(), ListAccountTransfersParams{
+		AccountID: account1.ID,
+		PageSize:  4,
+	})
+	require.NoError(t, err)
+	require.Len(t, page1, 4)
+	require.Equal(t, created[5].ID, page1[0].ID)
+
+	page2, err := testQueries.ListAccountTransfers(context.Background(), ListAccountTransfersParams{
+		AccountID: account1.ID,
+		BeforeID:  sql.NullInt64{Int64: page1[3].ID, Valid: true},
+		PageSize:  4,
+	})
+	require.NoError(t, err)
+	require.Len(t, page2, 2)
+	require.Equal(t, created[0].ID, page2[1].ID)
+
+	outgoing, err := testQueries.ListAccountTransfers(context.Background(), ListAccountTransfersParams{
+		AccountID: account1.ID,
+		Direction: sql.NullString{String: "out", Valid: true},
+		MinAmount: sql.NullInt64{Int64: 20, Valid: true},
+		MaxAmount: sql.NullInt64{Int64: 50, Valid: true},
+		PageSize:  10,
+	})
+	require.NoError(t, err)
+	require.Len(t, outgoing, 1)
+	require.Equal(t, created[2].ID, outgoing[0].ID)
+
+	future, err := testQueries.ListAccountTransfers(context.Background(), ListAccountTransfersParams{
+		AccountID: account1.ID,
+		StartTime: sql.NullTime{Time: time.Now().Add(time.Hour), Valid: true},
+		PageSize:  10,
+	})
+	require.NoError(t, err)
+	require.Empty(t, future)
+}
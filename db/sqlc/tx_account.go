@@ -0,0 +1,63 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// account member roles
+const (
+	MemberOwner   = "owner"
+	MemberCoOwner = "co_owner"
+	MemberViewer  = "viewer"
+)
+
+// SpendingRoles are the member roles that may move money out of an account
+var SpendingRoles = []string{MemberOwner, MemberCoOwner}
+
+var ErrNotAllowedToSpend = errors.New("user may not move money out of the account")
+
+// checkSpender checks the user is a member of the account that may move money out of it. Transfers made later
+// on behalf of a user check again when they run, since the user may have left the account in the meantime.
+func checkSpender(ctx context.Context, q *Queries, accountID int64, username string) error {
+	member, err := q.GetAccountMember(ctx, GetAccountMemberParams{
+		AccountID: accountID,
+		Username:  username,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%w: %s is not a member of account %d", ErrNotAllowedToSpend, username, accountID)
+		}
+		return err
+	}
+
+	if !slices.Contains(SpendingRoles, member.Role) {
+		return fmt.Errorf("%w: %s is a %s of account %d", ErrNotAllowedToSpend, username, member.Role, accountID)
+	}
+	return nil
+}
+
+// CreateAccountTx opens an account and makes its owner the first member of it
+func (store *SQLStore) CreateAccountTx(ctx context.Context, arg CreateAccountParams) (Account, error) {
+	var account Account
+
+	err := store.runTx(ctx, sql.LevelReadCommitted, func(q *Queries) error {
+		var err error
+		account, err = q.CreateAccount(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		_, err = q.CreateAccountMember(ctx, CreateAccountMemberParams{
+			AccountID: account.ID,
+			Username:  account.Owner,
+			Role:      MemberOwner,
+		})
+		return err
+	})
+
+	return account, err
+}
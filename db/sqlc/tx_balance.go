@@ -0,0 +1,100 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+type BalanceAsOfTxParams struct {
+	AccountID int64     `json:"account_id"`
+	AsOf      time.Time `json:"as_of"`
+}
+
+type BalanceAsOfTxResult struct {
+	AccountID int64     `json:"account_id"`
+	Currency  string    `json:"currency"`
+	AsOf      time.Time `json:"as_of"`
+	Balance   int64     `json:"balance"`
+	// SnapshotTakenAt is when the snapshot the balance starts from was taken, nil when there is none.
+	// It is after AsOf when the balance was worked back from a later snapshot.
+	SnapshotTakenAt *time.Time `json:"snapshot_taken_at,omitempty"`
+}
+
+// BalanceAsOfTx computes the balance of an account from the entries created before AsOf.
+// It starts from the latest balance snapshot taken at or before AsOf so that only the entries
+// since then are summed. Dates before the first snapshot, such as those before snapshots were taken at all,
+// are worked back from the earliest snapshot after AsOf, and from the current balance when there is no snapshot yet.
+func (store *SQLStore) BalanceAsOfTx(ctx context.Context, arg BalanceAsOfTxParams) (BalanceAsOfTxResult, error) {
+	result := BalanceAsOfTxResult{
+		AccountID: arg.AccountID,
+		AsOf:      arg.AsOf,
+	}
+
+	err := store.runTx(ctx, sql.LevelRepeatableRead, func(q *Queries) error {
+		account, err := q.GetAccount(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+		result.Currency = account.Currency
+
+		snapshot, err := q.GetBalanceSnapshotBefore(ctx, GetBalanceSnapshotBeforeParams{
+			AccountID: arg.AccountID,
+			AsOf:      arg.AsOf,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			return balanceBeforeSnapshot(ctx, q, arg, &result)
+		}
+		if err != nil {
+			return err
+		}
+
+		total, err := q.GetEntriesTotalBetween(ctx, GetEntriesTotalBetweenParams{
+			AccountID: arg.AccountID,
+			StartTime: snapshot.TakenAt,
+			EndTime:   arg.AsOf,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Balance = snapshot.Balance + total
+		result.SnapshotTakenAt = &snapshot.TakenAt
+		return nil
+	})
+
+	return result, err
+}
+
+// balanceBeforeSnapshot works the balance at arg.AsOf back from the earliest snapshot taken after it,
+// or from the current balance when there is none
+func balanceBeforeSnapshot(ctx context.Context, q *Queries, arg BalanceAsOfTxParams, result *BalanceAsOfTxResult) error {
+	snapshot, err := q.GetBalanceSnapshotAfter(ctx, GetBalanceSnapshotAfterParams{
+		AccountID: arg.AccountID,
+		AsOf:      arg.AsOf,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		result.Balance, err = q.GetAccountBalanceAt(ctx, GetAccountBalanceAtParams{
+			At:        arg.AsOf,
+			AccountID: arg.AccountID,
+		})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	total, err := q.GetEntriesTotalBetween(ctx, GetEntriesTotalBetweenParams{
+		AccountID: arg.AccountID,
+		StartTime: arg.AsOf,
+		EndTime:   snapshot.TakenAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	result.Balance = snapshot.Balance - total
+	result.SnapshotTakenAt = &snapshot.TakenAt
+	return nil
+}
@@ -0,0 +1,106 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/haniifac/simplebank/exchange"
+)
+
+var (
+	ErrQuoteNotFound  = errors.New("exchange quote not found")
+	ErrQuoteExpired   = errors.New("exchange quote has expired")
+	ErrQuoteUsed      = errors.New("exchange quote has already been used")
+	ErrQuoteMismatch  = errors.New("exchange quote does not match the transfer currencies")
+	ErrAmountTooSmall = errors.New("amount is too small to convert")
+)
+
+type ExchangeTransferTxParams struct {
+	FromAccountID int64     `json:"from_account_id"`
+	ToAccountID   int64     `json:"to_account_id"`
+	Amount        int64     `json:"amount"`
+	QuoteID       uuid.UUID `json:"quote_id"`
+	// Username must own the quote
+	Username       string                `json:"username"`
+	IdempotencyKey *IdempotencyKeyParams `json:"-"`
+}
+
+// ExchangeTransferTx transfers money between accounts in different currencies at the rate locked by a quote.
+// The source account is debited Amount in its currency and the destination is credited the converted amount
+// in its own currency. Both amounts and the applied rate are stored on the transfer, and the quote can only be used once.
+func (store *SQLStore) ExchangeTransferTx(ctx context.Context, arg ExchangeTransferTxParams) (TransferTxResult, error) {
+	var result TransferTxResult
+
+	err := store.execTx(ctx, store.transferIsolation(ctx), func(q *Queries) error {
+		if arg.IdempotencyKey != nil {
+			replayed, err := replayIdempotencyKey(ctx, q, arg.IdempotencyKey, &result)
+			if err != nil || replayed {
+				return err
+			}
+		}
+
+		quote, err := q.GetExchangeQuoteForUpdate(ctx, arg.QuoteID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrQuoteNotFound
+			}
+			return err
+		}
+
+		switch {
+		case quote.Username != arg.Username:
+			return ErrQuoteNotFound
+		case quote.UsedAt.Valid:
+			return ErrQuoteUsed
+		case time.Now().After(quote.ExpiresAt):
+			return ErrQuoteExpired
+		}
+
+		fromAccount, err := q.GetAccount(ctx, arg.FromAccountID)
+		if err != nil {
+			return err
+		}
+		toAccount, err := q.GetAccount(ctx, arg.ToAccountID)
+		if err != nil {
+			return err
+		}
+		if fromAccount.Currency != quote.FromCurrency || toAccount.Currency != quote.ToCurrency {
+			return ErrQuoteMismatch
+		}
+
+		toAmount, err := exchange.Convert(arg.Amount, quote.Rate)
+		if err != nil {
+			return err
+		}
+		if toAmount <= 0 {
+			return fmt.Errorf("%w: %d at rate %s", ErrAmountTooSmall, arg.Amount, quote.Rate)
+		}
+
+		result, err = transferMoney(ctx, q, CreateTransferParams{
+			FromAccountID: arg.FromAccountID,
+			ToAccountID:   arg.ToAccountID,
+			Amount:        arg.Amount,
+			ToAmount:      sql.NullInt64{Int64: toAmount, Valid: true},
+			ExchangeRate:  sql.NullString{String: quote.Rate, Valid: true},
+		}, &store.options.TransferLimits)
+		if err != nil {
+			return err
+		}
+
+		if _, err = q.UseExchangeQuote(ctx, quote.ID); err != nil {
+			return err
+		}
+
+		if arg.IdempotencyKey != nil {
+			return saveIdempotencyKey(ctx, q, arg.IdempotencyKey, result)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
@@ -0,0 +1,237 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// fee kinds
+const (
+	FeeTransfer     = "transfer"
+	FeeMonthly      = "monthly"
+	FeeBelowMinimum = "below_minimum"
+)
+
+// periodicFees are the fees charged once a month, in the order they are charged, with their statement description
+var periodicFees = []struct {
+	kind        string
+	description string
+}{
+	{FeeMonthly, "monthly fee"},
+	{FeeBelowMinimum, "below minimum balance fee"},
+}
+
+type chargeFeeParams struct {
+	Account     Account
+	Schedule    FeeSchedule
+	Kind        string
+	Amount      int64
+	Description string
+	PeriodEnd   sql.NullTime
+	TransferID  sql.NullInt64
+}
+
+// chargeFee moves a fee from an account to the bank's fee revenue account of its currency and records the charge.
+// Like a transfer, a fee can only be paid from the available balance. Callers that already hold other locks must
+// have locked the revenue account with them, the locks taken here are then only taken again.
+func chargeFee(ctx context.Context, q *Queries, arg chargeFeeParams) (FeeCharge, error) {
+	var charge FeeCharge
+
+	revenue, err := bankAccount(ctx, q, BankFeeRevenue, arg.Account.Currency)
+	if err != nil {
+		return charge, err
+	}
+
+	accounts, err := lockAccounts(ctx, q, arg.Account.ID, revenue.AccountID)
+	if err != nil {
+		return charge, err
+	}
+	held, err := q.GetHeldAmount(ctx, arg.Account.ID)
+	if err != nil {
+		return charge, err
+	}
+	if AvailableBalance(accounts[arg.Account.ID], held) < arg.Amount {
+		return charge, fmt.Errorf("%w for the %s fee of account %d", ErrInsufficientFunds, arg.Kind, arg.Account.ID)
+	}
+
+	journal, err := postJournal(ctx, q, PostJournalTxParams{
+		Description: arg.Description,
+		Postings: []Posting{
+			{AccountID: arg.Account.ID, Amount: -arg.Amount},
+			{AccountID: revenue.AccountID, Amount: arg.Amount},
+		},
+	}, false)
+	if err != nil {
+		return charge, err
+	}
+
+	return q.CreateFeeCharge(ctx, CreateFeeChargeParams{
+		AccountID:     arg.Account.ID,
+		FeeScheduleID: arg.Schedule.ID,
+		Kind:          arg.Kind,
+		Amount:        arg.Amount,
+		PeriodEnd:     arg.PeriodEnd,
+		TransferID:    arg.TransferID,
+		JournalID:     journal.Journal.ID,
+	})
+}
+
+// transferFeeSchedule returns the fee schedule of an account when it charges a fee for outgoing transfers, nil otherwise
+func transferFeeSchedule(ctx context.Context, q *Queries, account Account) (*FeeSchedule, error) {
+	if !account.FeeScheduleID.Valid {
+		return nil, nil
+	}
+
+	schedule, err := q.GetFeeSchedule(ctx, account.FeeScheduleID.Int64)
+	if err != nil {
+		return nil, err
+	}
+	if schedule.TransferFee == 0 {
+		return nil, nil
+	}
+
+	return &schedule, nil
+}
+
+// chargeTransferFee charges the transfer fee of the schedule found by transferFeeSchedule for a transfer
+// sent by the account. It returns nil when no fee is due.
+func chargeTransferFee(ctx context.Context, q *Queries, account Account, schedule *FeeSchedule, transfer Transfer) (*FeeCharge, error) {
+	if schedule == nil {
+		return nil, nil
+	}
+
+	charge, err := chargeFee(ctx, q, chargeFeeParams{
+		Account:     account,
+		Schedule:    *schedule,
+		Kind:        FeeTransfer,
+		Amount:      schedule.TransferFee,
+		Description: fmt.Sprintf("transfer fee for transfer %d", transfer.ID),
+		TransferID:  sql.NullInt64{Int64: transfer.ID, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &charge, nil
+}
+
+type ChargePeriodicFeesTxParams struct {
+	AccountID int64 `json:"account_id"`
+	// PeriodEnd is the first day of the month after the one the fees are charged for
+	PeriodEnd time.Time `json:"period_end"`
+}
+
+// ChargePeriodicFeesTx charges the monthly maintenance fee and the below minimum balance fee of an account
+// for the month before PeriodEnd. Every fee is charged in its own transaction, so a fee the account cannot pay
+// does not keep the other one from being charged. Fees that were already charged for the month are skipped,
+// so it is safe to run again. Once every fee of the month is charged the account's fees_charged_until moves
+// on to PeriodEnd. The minimum balance is checked against the balance at the end of the month.
+// The charges made are returned even when another fee could not be charged.
+func (store *SQLStore) ChargePeriodicFeesTx(ctx context.Context, arg ChargePeriodicFeesTxParams) ([]FeeCharge, error) {
+	charges := []FeeCharge{}
+
+	var failed []error
+	for _, fee := range periodicFees {
+		var charge *FeeCharge
+
+		err := store.execTx(ctx, store.transferIsolation(ctx), func(q *Queries) error {
+			var err error
+			charge, err = chargePeriodicFee(ctx, q, arg, fee.kind, fee.description)
+			return err
+		})
+		if err != nil {
+			if !errors.Is(err, ErrInsufficientFunds) {
+				return charges, err
+			}
+			failed = append(failed, err)
+			continue
+		}
+
+		if charge != nil {
+			charges = append(charges, *charge)
+		}
+	}
+
+	if len(failed) > 0 {
+		return charges, errors.Join(failed...)
+	}
+
+	err := store.SettleFeePeriod(ctx, SettleFeePeriodParams{
+		ID:        arg.AccountID,
+		PeriodEnd: arg.PeriodEnd,
+	})
+	return charges, err
+}
+
+// chargePeriodicFee charges one kind of periodic fee of an account for the month before arg.PeriodEnd.
+// It returns nil when the fee is not due or was already charged for the month.
+func chargePeriodicFee(ctx context.Context, q *Queries, arg ChargePeriodicFeesTxParams, kind string, description string) (*FeeCharge, error) {
+	account, err := q.GetAccount(ctx, arg.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	if !account.FeeScheduleID.Valid {
+		return nil, nil
+	}
+
+	schedule, err := q.GetFeeSchedule(ctx, account.FeeScheduleID.Int64)
+	if err != nil {
+		return nil, err
+	}
+
+	var amount int64
+	switch kind {
+	case FeeMonthly:
+		amount = schedule.MonthlyFee
+	case FeeBelowMinimum:
+		if schedule.BelowMinimumFee == 0 {
+			return nil, nil
+		}
+
+		balance, err := q.GetAccountBalanceAt(ctx, GetAccountBalanceAtParams{
+			At:        arg.PeriodEnd,
+			AccountID: account.ID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if balance < schedule.MinimumBalance {
+			amount = schedule.BelowMinimumFee
+		}
+	}
+	if amount == 0 {
+		return nil, nil
+	}
+
+	periodEnd := sql.NullTime{Time: arg.PeriodEnd, Valid: true}
+
+	_, err = q.GetPeriodicFeeCharge(ctx, GetPeriodicFeeChargeParams{
+		AccountID: account.ID,
+		Kind:      kind,
+		PeriodEnd: periodEnd,
+	})
+	if err == nil {
+		return nil, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	month := arg.PeriodEnd.AddDate(0, -1, 0).Format("2006-01")
+	charge, err := chargeFee(ctx, q, chargeFeeParams{
+		Account:     account,
+		Schedule:    schedule,
+		Kind:        kind,
+		Amount:      amount,
+		Description: fmt.Sprintf("%s %s", description, month),
+		PeriodEnd:   periodEnd,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &charge, nil
+}
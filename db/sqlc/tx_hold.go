@@ -0,0 +1,132 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// hold statuses
+const (
+	HoldActive   = "active"
+	HoldCaptured = "captured"
+	HoldReleased = "released"
+	HoldExpired  = "expired"
+)
+
+var (
+	ErrHoldNotActive      = errors.New("hold is not active")
+	ErrHoldExpired        = errors.New("hold has expired")
+	ErrCaptureExceedsHold = errors.New("capture amount exceeds the held amount")
+)
+
+type CreateHoldTxParams struct {
+	AccountID   int64     `json:"account_id"`
+	ToAccountID int64     `json:"to_account_id"`
+	Amount      int64     `json:"amount"`
+	Description string    `json:"description"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// CreateHoldTx reserves funds on an account so they can later be captured by the receiving account.
+// The accounts are locked like for a transfer, so a hold and a transfer can never spend the same funds.
+func (store *SQLStore) CreateHoldTx(ctx context.Context, arg CreateHoldTxParams) (Hold, error) {
+	var hold Hold
+
+	err := store.execTx(ctx, store.transferIsolation(ctx), func(q *Queries) error {
+		accounts, err := lockAccounts(ctx, q, arg.AccountID, arg.ToAccountID)
+		if err != nil {
+			return err
+		}
+		for _, account := range accounts {
+			if account.Status != AccountActive {
+				return fmt.Errorf("%w: account %d is %s", ErrAccountNotActive, account.ID, account.Status)
+			}
+		}
+
+		held, err := q.GetHeldAmount(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+		if AvailableBalance(accounts[arg.AccountID], held) < arg.Amount {
+			return ErrInsufficientFunds
+		}
+
+		hold, err = q.CreateHold(ctx, CreateHoldParams{
+			AccountID:   arg.AccountID,
+			ToAccountID: arg.ToAccountID,
+			Amount:      arg.Amount,
+			Description: arg.Description,
+			ExpiresAt:   arg.ExpiresAt,
+		})
+		return err
+	})
+
+	return hold, err
+}
+
+type CaptureHoldTxParams struct {
+	HoldID int64 `json:"hold_id"`
+	// Amount to capture, zero captures the full hold. The rest of the hold is released.
+	Amount int64 `json:"amount"`
+}
+
+type CaptureHoldTxResult struct {
+	Hold     Hold             `json:"hold"`
+	Transfer TransferTxResult `json:"transfer"`
+}
+
+// CaptureHoldTx settles an active hold by transferring the captured amount to the receiving account
+func (store *SQLStore) CaptureHoldTx(ctx context.Context, arg CaptureHoldTxParams) (CaptureHoldTxResult, error) {
+	var result CaptureHoldTxResult
+
+	err := store.execTx(ctx, store.transferIsolation(ctx), func(q *Queries) error {
+		hold, err := q.GetHoldForUpdate(ctx, arg.HoldID)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case hold.Status != HoldActive:
+			return fmt.Errorf("%w: hold %d is %s", ErrHoldNotActive, hold.ID, hold.Status)
+		case !hold.ExpiresAt.After(time.Now()):
+			return ErrHoldExpired
+		case arg.Amount > hold.Amount:
+			return ErrCaptureExceedsHold
+		}
+
+		amount := arg.Amount
+		if amount == 0 {
+			amount = hold.Amount
+		}
+
+		// the hold is resolved first so its funds count as available for the transfer
+		_, err = q.ResolveHold(ctx, ResolveHoldParams{
+			ID:             hold.ID,
+			Status:         HoldCaptured,
+			CapturedAmount: sql.NullInt64{Int64: amount, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Transfer, err = transferMoney(ctx, q, CreateTransferParams{
+			FromAccountID: hold.AccountID,
+			ToAccountID:   hold.ToAccountID,
+			Amount:        amount,
+		}, &store.options.TransferLimits)
+		if err != nil {
+			return err
+		}
+
+		result.Hold, err = q.UpdateHoldTransfer(ctx, UpdateHoldTransferParams{
+			ID:         hold.ID,
+			TransferID: sql.NullInt64{Int64: result.Transfer.Transfer.ID, Valid: true},
+		})
+		return err
+	})
+
+	return result, err
+}
@@ -0,0 +1,163 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/haniifac/simplebank/interest"
+)
+
+var ErrNoInterestAccrued = errors.New("no interest accrued for the period")
+
+type AccrueInterestTxParams struct {
+	AccountID int64 `json:"account_id"`
+	// Date is the UTC day interest is accrued for, on the balance at the end of it
+	Date     time.Time         `json:"date"`
+	Rate     *big.Rat          `json:"rate"`
+	DayCount interest.DayCount `json:"day_count"`
+}
+
+// AccrueInterestTx records the interest an account earned on a day.
+// It returns sql.ErrNoRows when interest was already accrued for the day.
+func (store *SQLStore) AccrueInterestTx(ctx context.Context, arg AccrueInterestTxParams) (InterestAccrual, error) {
+	var accrual InterestAccrual
+
+	err := store.execTx(ctx, sql.LevelRepeatableRead, func(q *Queries) error {
+		balance, err := q.GetAccountBalanceAt(ctx, GetAccountBalanceAtParams{
+			At:        arg.Date.AddDate(0, 0, 1),
+			AccountID: arg.AccountID,
+		})
+		if err != nil {
+			return err
+		}
+
+		amount := interest.Daily(balance, arg.Rate, arg.DayCount, arg.Date)
+		accrual, err = q.CreateInterestAccrual(ctx, CreateInterestAccrualParams{
+			AccountID:   arg.AccountID,
+			AccrualDate: arg.Date,
+			Balance:     balance,
+			Rate:        arg.Rate.FloatString(10),
+			Amount:      interest.Format(amount),
+		})
+		return err
+	})
+
+	return accrual, err
+}
+
+type PostInterestTxParams struct {
+	AccountID int64 `json:"account_id"`
+	// PeriodEnd is the first day of the month after the period, accruals dated before it are posted
+	PeriodEnd time.Time `json:"period_end"`
+}
+
+type PostInterestTxResult struct {
+	Posting InterestPosting `json:"posting"`
+	// Journal is empty when no whole minor unit was accrued
+	Journal PostJournalTxResult `json:"journal"`
+}
+
+// PostInterestTx credits the interest accrued on an account before PeriodEnd from the bank's interest expense
+// account of the same currency. Only whole minor units are posted, the fraction left over is carried over
+// to the next posting. Closed accounts cannot be credited, so everything they accrued is carried over.
+func (store *SQLStore) PostInterestTx(ctx context.Context, arg PostInterestTxParams) (PostInterestTxResult, error) {
+	var result PostInterestTxResult
+
+	err := store.execTx(ctx, store.transferIsolation(ctx), func(q *Queries) error {
+		result = PostInterestTxResult{}
+
+		accruals, err := q.ListUnpostedInterestAccruals(ctx, ListUnpostedInterestAccrualsParams{
+			AccountID: arg.AccountID,
+			PeriodEnd: arg.PeriodEnd,
+		})
+		if err != nil {
+			return err
+		}
+		if len(accruals) == 0 {
+			return ErrNoInterestAccrued
+		}
+
+		accrued := new(big.Rat)
+		last, err := q.GetLastInterestPosting(ctx, arg.AccountID)
+		switch {
+		case err == nil:
+			if err := addInterest(accrued, last.Carry); err != nil {
+				return err
+			}
+		case !errors.Is(err, sql.ErrNoRows):
+			return err
+		}
+		for _, accrual := range accruals {
+			if err := addInterest(accrued, accrual.Amount); err != nil {
+				return err
+			}
+		}
+
+		amount, carry, err := interest.Split(accrued)
+		if err != nil {
+			return err
+		}
+
+		account, err := q.GetAccount(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+		if account.Status == AccountClosed {
+			amount, carry = 0, accrued
+		}
+
+		var journalID sql.NullInt64
+		if amount > 0 {
+			expense, err := bankAccount(ctx, q, BankInterestExpense, account.Currency)
+			if err != nil {
+				return err
+			}
+
+			result.Journal, err = postJournal(ctx, q, PostJournalTxParams{
+				Description: fmt.Sprintf("interest %s", arg.PeriodEnd.AddDate(0, -1, 0).Format("2006-01")),
+				Postings: []Posting{
+					{AccountID: expense.AccountID, Amount: -amount},
+					{AccountID: account.ID, Amount: amount},
+				},
+			}, false)
+			if err != nil {
+				return err
+			}
+			journalID = sql.NullInt64{Int64: result.Journal.Journal.ID, Valid: true}
+		}
+
+		result.Posting, err = q.CreateInterestPosting(ctx, CreateInterestPostingParams{
+			AccountID: account.ID,
+			PeriodEnd: arg.PeriodEnd,
+			Accrued:   interest.Format(accrued),
+			Amount:    amount,
+			Carry:     interest.Format(carry),
+			JournalID: journalID,
+		})
+		if err != nil {
+			return err
+		}
+
+		return q.SetInterestAccrualsPosted(ctx, SetInterestAccrualsPostedParams{
+			PostingID: sql.NullInt64{Int64: result.Posting.ID, Valid: true},
+			AccountID: account.ID,
+			PeriodEnd: arg.PeriodEnd,
+		})
+	})
+
+	return result, err
+}
+
+// addInterest adds a stored interest amount to sum
+func addInterest(sum *big.Rat, amount string) error {
+	r, err := interest.Parse(amount)
+	if err != nil {
+		return err
+	}
+	sum.Add(sum, r)
+	return nil
+}
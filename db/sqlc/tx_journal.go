@@ -0,0 +1,139 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var (
+	ErrInvalidJournal    = errors.New("journal needs at least two postings with non-zero amounts")
+	ErrUnbalancedJournal = errors.New("journal postings do not sum to zero per currency")
+)
+
+// Posting moves Amount in or out (when negative) of an account as part of a journal
+type Posting struct {
+	AccountID int64 `json:"account_id"`
+	Amount    int64 `json:"amount"`
+}
+
+type PostJournalTxParams struct {
+	Description string    `json:"description"`
+	Postings    []Posting `json:"postings"`
+}
+
+type PostJournalTxResult struct {
+	Journal Journal `json:"journal"`
+	// Entries in the order of the postings
+	Entries []Entry `json:"entries"`
+	// Accounts after the update in ascending id order
+	Accounts []Account `json:"accounts"`
+}
+
+// PostJournalTx books any number of postings atomically as a single journal.
+// The postings must sum to zero per currency, every account must be active and, like a transfer,
+// an account can only be debited from its available balance.
+func (store *SQLStore) PostJournalTx(ctx context.Context, arg PostJournalTxParams) (PostJournalTxResult, error) {
+	var result PostJournalTxResult
+
+	err := store.execTx(ctx, store.transferIsolation(ctx), func(q *Queries) error {
+		var err error
+		result, err = postJournal(ctx, q, arg, true)
+		return err
+	})
+
+	return result, err
+}
+
+// postJournal books a journal using the given queries so it can be part of a larger transaction.
+// No account may go below its overdraft limit. With checkAccounts the accounts must also be active and funds
+// reserved by holds cannot be debited. The bank's own postings skip those checks: fees are charged and interest
+// is paid on frozen accounts too, and chargeFee checks the available balance itself.
+func postJournal(ctx context.Context, q *Queries, arg PostJournalTxParams, checkAccounts bool) (PostJournalTxResult, error) {
+	var result PostJournalTxResult
+
+	if len(arg.Postings) < 2 {
+		return result, ErrInvalidJournal
+	}
+
+	ids := make([]int64, len(arg.Postings))
+	changes := make(map[int64]int64)
+	for i, posting := range arg.Postings {
+		if posting.Amount == 0 {
+			return result, ErrInvalidJournal
+		}
+		ids[i] = posting.AccountID
+		changes[posting.AccountID] += posting.Amount
+	}
+
+	accounts, err := lockAccounts(ctx, q, ids...)
+	if err != nil {
+		return result, err
+	}
+
+	sums := make(map[string]int64)
+	for id, change := range changes {
+		account := accounts[id]
+		sums[account.Currency] += change
+
+		if checkAccounts && account.Status != AccountActive {
+			return result, fmt.Errorf("%w: account %d is %s", ErrAccountNotActive, id, account.Status)
+		}
+
+		var held int64
+		if checkAccounts && change < 0 {
+			held, err = q.GetHeldAmount(ctx, id)
+			if err != nil {
+				return result, err
+			}
+		}
+		if AvailableBalance(account, held)+change < 0 {
+			return result, fmt.Errorf("%w on account %d", ErrInsufficientFunds, id)
+		}
+	}
+	for currency, sum := range sums {
+		if sum != 0 {
+			return result, fmt.Errorf("%w: %s is off by %d", ErrUnbalancedJournal, currency, sum)
+		}
+	}
+
+	result.Journal, err = q.CreateJournal(ctx, arg.Description)
+	if err != nil {
+		return result, err
+	}
+
+	result.Entries = make([]Entry, len(arg.Postings))
+	for i, posting := range arg.Postings {
+		result.Entries[i], err = q.CreateEntry(ctx, CreateEntryParams{
+			AccountID: posting.AccountID,
+			Amount:    posting.Amount,
+			JournalID: sql.NullInt64{Int64: result.Journal.ID, Valid: true},
+		})
+		if err != nil {
+			return result, err
+		}
+	}
+
+	// balances are updated in the same ascending order the accounts were locked in
+	sorted := make([]int64, 0, len(changes))
+	for id := range changes {
+		sorted = append(sorted, id)
+	}
+	slices.Sort(sorted)
+
+	result.Accounts = make([]Account, 0, len(sorted))
+	for _, id := range sorted {
+		account, err := q.AddAccountBalance(ctx, AddAccountBalanceParams{
+			ID:     id,
+			Amount: changes[id],
+		})
+		if err != nil {
+			return result, err
+		}
+		result.Accounts = append(result.Accounts, account)
+	}
+
+	return result, nil
+}
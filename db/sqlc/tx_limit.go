@@ -0,0 +1,116 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrTransferLimitExceeded = errors.New("transfer limit exceeded")
+
+// TransferLimits are the outgoing limits of accounts that have none of their own, 0 means unlimited
+type TransferLimits struct {
+	Daily   int64
+	Monthly int64
+}
+
+// LimitUsage is how much of an outgoing limit is used in the current period
+type LimitUsage struct {
+	// Limit and Remaining are nil when the account has no limit for the period
+	Limit     *int64    `json:"limit"`
+	Used      int64     `json:"used"`
+	Remaining *int64    `json:"remaining"`
+	ResetsAt  time.Time `json:"resets_at"`
+}
+
+type AccountLimits struct {
+	AccountID int64      `json:"account_id"`
+	Currency  string     `json:"currency"`
+	Daily     LimitUsage `json:"daily"`
+	Monthly   LimitUsage `json:"monthly"`
+}
+
+// AccountLimitsTx returns the used and remaining outgoing limits of an account in the current UTC day and month
+func (store *SQLStore) AccountLimitsTx(ctx context.Context, accountID int64) (AccountLimits, error) {
+	var limits AccountLimits
+
+	err := store.runTx(ctx, sql.LevelRepeatableRead, func(q *Queries) error {
+		account, err := q.GetAccount(ctx, accountID)
+		if err != nil {
+			return err
+		}
+
+		limits, err = accountLimits(ctx, q, account, store.options.TransferLimits, time.Now())
+		return err
+	})
+
+	return limits, err
+}
+
+// accountLimits sums the transfers that left the account in the day and month of now.
+// Reversals are not counted, they return money the account received.
+func accountLimits(ctx context.Context, q *Queries, account Account, defaults TransferLimits, now time.Time) (AccountLimits, error) {
+	year, month, day := now.UTC().Date()
+	dayStart := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	monthStart := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+
+	totals, err := q.GetOutgoingTransferTotals(ctx, GetOutgoingTransferTotalsParams{
+		DayStart:   dayStart,
+		AccountID:  account.ID,
+		MonthStart: monthStart,
+	})
+	if err != nil {
+		return AccountLimits{}, err
+	}
+
+	return AccountLimits{
+		AccountID: account.ID,
+		Currency:  account.Currency,
+		Daily:     limitUsage(account.DailyLimit, defaults.Daily, totals.DayTotal, dayStart.AddDate(0, 0, 1)),
+		Monthly:   limitUsage(account.MonthlyLimit, defaults.Monthly, totals.MonthTotal, monthStart.AddDate(0, 1, 0)),
+	}, nil
+}
+
+// limitUsage applies the account's own limit, or the default when it has none
+func limitUsage(limit sql.NullInt64, defaultLimit int64, used int64, resetsAt time.Time) LimitUsage {
+	usage := LimitUsage{
+		Used:     used,
+		ResetsAt: resetsAt,
+	}
+
+	if !limit.Valid {
+		if defaultLimit <= 0 {
+			return usage
+		}
+		limit = sql.NullInt64{Int64: defaultLimit, Valid: true}
+	}
+
+	remaining := max(limit.Int64-used, 0)
+	usage.Limit = &limit.Int64
+	usage.Remaining = &remaining
+	return usage
+}
+
+// allows reports whether amount fits in the remaining limit
+func (usage LimitUsage) allows(amount int64) bool {
+	return usage.Remaining == nil || amount <= *usage.Remaining
+}
+
+// checkTransferLimits fails with ErrTransferLimitExceeded when amount does not fit in the daily or monthly
+// limit of the account. The account must be locked so that concurrent transfers are counted.
+func checkTransferLimits(ctx context.Context, q *Queries, account Account, amount int64, defaults TransferLimits) error {
+	limits, err := accountLimits(ctx, q, account, defaults, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if !limits.Daily.allows(amount) {
+		return fmt.Errorf("%w: account %d has %d of its daily limit left", ErrTransferLimitExceeded, account.ID, *limits.Daily.Remaining)
+	}
+	if !limits.Monthly.allows(amount) {
+		return fmt.Errorf("%w: account %d has %d of its monthly limit left", ErrTransferLimitExceeded, account.ID, *limits.Monthly.Remaining)
+	}
+	return nil
+}
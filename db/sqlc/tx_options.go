@@ -0,0 +1,109 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"expvar"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	deadlockDetected     = "40P01"
+	serializationFailure = "40001"
+)
+
+// retry metrics, published under /debug/vars
+var (
+	txRetries          = expvar.NewMap("db_tx_retries")
+	txRetriedSucceeded = expvar.NewInt("db_tx_retried_succeeded")
+	txRetriesExhausted = expvar.NewInt("db_tx_retries_exhausted")
+)
+
+// TxOptions controls how the store runs the transactions that move money
+type TxOptions struct {
+	// TransferIsolation is the isolation level of those transactions, unless a call asks for another with WithIsolation
+	TransferIsolation sql.IsolationLevel
+	// MaxAttempts bounds how often a transaction is run when it keeps failing with a retryable error
+	MaxAttempts int
+	// BaseDelay is the backoff before the first retry, it doubles with every attempt up to MaxDelay
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// TransferLimits are the default outgoing limits of accounts
+	TransferLimits TransferLimits
+}
+
+func DefaultTxOptions() TxOptions {
+	return TxOptions{
+		TransferIsolation: sql.LevelDefault,
+		MaxAttempts:       5,
+		BaseDelay:         10 * time.Millisecond,
+		MaxDelay:          500 * time.Millisecond,
+	}
+}
+
+type isolationKey struct{}
+
+// WithIsolation makes the transactions that move money run at the given isolation level when called with
+// the returned context, instead of the configured TxOptions.TransferIsolation
+func WithIsolation(ctx context.Context, level sql.IsolationLevel) context.Context {
+	return context.WithValue(ctx, isolationKey{}, level)
+}
+
+// transferIsolation is the isolation level the call asked for with WithIsolation, or the configured one
+func (store *SQLStore) transferIsolation(ctx context.Context) sql.IsolationLevel {
+	if level, ok := ctx.Value(isolationKey{}).(sql.IsolationLevel); ok {
+		return level
+	}
+	return store.options.TransferIsolation
+}
+
+// backoff returns the delay before the retry following the given attempt.
+// The delay is drawn from the upper half of the exponential backoff so concurrent retries spread out.
+func (options TxOptions) backoff(attempt int) time.Duration {
+	delay := options.BaseDelay << (attempt - 1)
+	if delay <= 0 || delay > options.MaxDelay {
+		delay = options.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+
+	half := delay / 2
+	return half + rand.N(delay-half+1)
+}
+
+// retryableTxError reports whether err is a deadlock or serialization failure that succeeds when the transaction is run again
+func retryableTxError(err error) (string, bool) {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return "", false
+	}
+
+	switch pqErr.Code {
+	case deadlockDetected, serializationFailure:
+		return string(pqErr.Code), true
+	}
+	return "", false
+}
+
+// ParseIsolationLevel parses an isolation level such as "serializable" or "read committed".
+// An empty string selects the database default.
+func ParseIsolationLevel(level string) (sql.IsolationLevel, error) {
+	switch strings.ReplaceAll(strings.ToLower(strings.TrimSpace(level)), "_", " ") {
+	case "":
+		return sql.LevelDefault, nil
+	case "read committed":
+		return sql.LevelReadCommitted, nil
+	case "repeatable read":
+		return sql.LevelRepeatableRead, nil
+	case "serializable":
+		return sql.LevelSerializable, nil
+	}
+	return sql.LevelDefault, fmt.Errorf("unsupported isolation level %q", level)
+}
@@ -0,0 +1,101 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryableTxError(t *testing.T) {
+	code, ok := retryableTxError(&pq.Error{Code: deadlockDetected})
+	require.True(t, ok)
+	require.Equal(t, deadlockDetected, code)
+
+	_, ok = retryableTxError(fmt.Errorf("tx err: %w, rb err: %v", &pq.Error{Code: serializationFailure}, errors.New("closed")))
+	require.True(t, ok)
+
+	_, ok = retryableTxError(&pq.Error{Code: "23505"})
+	require.False(t, ok)
+
+	_, ok = retryableTxError(ErrInsufficientFunds)
+	require.False(t, ok)
+}
+
+func TestTxOptionsBackoff(t *testing.T) {
+	options := TxOptions{BaseDelay: 10 * time.Millisecond, MaxDelay: 50 * time.Millisecond}
+
+	for attempt := 1; attempt <= 10; attempt++ {
+		want := min(options.BaseDelay<<(attempt-1), options.MaxDelay)
+		delay := options.backoff(attempt)
+		require.GreaterOrEqual(t, delay, want/2)
+		require.LessOrEqual(t, delay, want)
+	}
+}
+
+func TestParseIsolationLevel(t *testing.T) {
+	for input, want := range map[string]sql.IsolationLevel{
+		"":                sql.LevelDefault,
+		"serializable":    sql.LevelSerializable,
+		"REPEATABLE_READ": sql.LevelRepeatableRead,
+		"read committed":  sql.LevelReadCommitted,
+	} {
+		level, err := ParseIsolationLevel(input)
+		require.NoError(t, err)
+		require.Equal(t, want, level)
+	}
+
+	_, err := ParseIsolationLevel("snapshot")
+	require.Error(t, err)
+}
+
+func TestWithIsolation(t *testing.T) {
+	options := DefaultTxOptions()
+	options.TransferIsolation = sql.LevelReadCommitted
+	store := &SQLStore{options: options}
+
+	require.Equal(t, sql.LevelReadCommitted, store.transferIsolation(context.Background()))
+
+	ctx := WithIsolation(context.Background(), sql.LevelSerializable)
+	require.Equal(t, sql.LevelSerializable, store.transferIsolation(ctx))
+}
+
+func TestTransferTxSerializable(t *testing.T) {
+	options := DefaultTxOptions()
+	options.TransferIsolation = sql.LevelSerializable
+	options.MaxAttempts = 20
+	store := NewStoreWithOptions(testDB, options)
+
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+
+	n := 10
+	errs := make(chan error)
+	for i := 0; i < n; i++ {
+		from, to := account1.ID, account2.ID
+		if i%2 == 1 {
+			from, to = to, from
+		}
+		go func() {
+			_, err := store.TransferTx(context.Background(), TransferTxParams{
+				FromAccountID: from,
+				ToAccountID:   to,
+				Amount:        10,
+			})
+			errs <- err
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		require.NoError(t, <-errs)
+	}
+
+	updated, err := testQueries.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance, updated.Balance)
+}
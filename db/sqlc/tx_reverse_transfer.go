@@ -0,0 +1,102 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math/big"
+
+	"github.com/haniifac/simplebank/exchange"
+)
+
+var (
+	ErrTransferIsReversal   = errors.New("a reversal cannot be reversed")
+	ErrReversalExceedsTotal = errors.New("reversal amount exceeds the amount left to reverse")
+	ErrReversalTooSmall     = errors.New("reversal amount is too small to convert")
+)
+
+type ReverseTransferTxParams struct {
+	TransferID int64 `json:"transfer_id"`
+	// Amount to reverse, zero reverses everything that has not been reversed yet
+	Amount int64 `json:"amount"`
+}
+
+// ReverseTransferTx moves money back from the receiving account of a transfer to its sender.
+// The reversal is booked as a new transfer linked to the original one, and the original transfer
+// row is locked so concurrent partial reversals cannot add up to more than the original amount.
+// Amounts are always in the currency of the sender of the original transfer.
+func (store *SQLStore) ReverseTransferTx(ctx context.Context, arg ReverseTransferTxParams) (TransferTxResult, error) {
+	var result TransferTxResult
+
+	err := store.execTx(ctx, store.transferIsolation(ctx), func(q *Queries) error {
+		original, err := q.GetTransferForUpdate(ctx, arg.TransferID)
+		if err != nil {
+			return err
+		}
+
+		if original.ReversedTransferID.Valid {
+			return ErrTransferIsReversal
+		}
+
+		reversed, err := q.GetReversedAmount(ctx, original.ID)
+		if err != nil {
+			return err
+		}
+
+		remaining := original.Amount - reversed.ReversedAmount
+		amount := arg.Amount
+		if amount == 0 {
+			amount = remaining
+		}
+		if amount <= 0 || amount > remaining {
+			return ErrReversalExceedsTotal
+		}
+
+		reversal := CreateTransferParams{
+			FromAccountID:      original.ToAccountID,
+			ToAccountID:        original.FromAccountID,
+			Amount:             amount,
+			ReversedTransferID: sql.NullInt64{Int64: original.ID, Valid: true},
+		}
+		if original.ExchangeRate.Valid {
+			reversal, err = exchangeReversal(original, reversed, reversal)
+			if err != nil {
+				return err
+			}
+		}
+
+		result, err = transferMoney(ctx, q, reversal, nil)
+		return err
+	})
+
+	return result, err
+}
+
+// exchangeReversal turns the reversal of a cross currency transfer into a transfer at the rate of the original.
+// The sender gets amount back in its own currency while the receiver is debited the matching share of the
+// converted amount, and the last reversal takes whatever is left so the totals match the original exactly.
+func exchangeReversal(original Transfer, reversed GetReversedAmountRow, reversal CreateTransferParams) (CreateTransferParams, error) {
+	remaining := original.ToAmount.Int64 - reversed.ReversedToAmount
+
+	debit := remaining
+	if reversal.Amount < original.Amount-reversed.ReversedAmount {
+		converted, err := exchange.Convert(reversal.Amount, original.ExchangeRate.String)
+		if err != nil {
+			return reversal, err
+		}
+		debit = min(converted, remaining)
+	}
+	if debit <= 0 {
+		return reversal, ErrReversalTooSmall
+	}
+
+	rate, err := exchange.ParseRate(original.ExchangeRate.String)
+	if err != nil {
+		return reversal, err
+	}
+
+	reversal.ToAmount = sql.NullInt64{Int64: reversal.Amount, Valid: true}
+	reversal.Amount = debit
+	reversal.ExchangeRate = sql.NullString{String: exchange.FormatRate(new(big.Rat).Inv(rate)), Valid: true}
+	return reversal, nil
+}
@@ -0,0 +1,63 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+const (
+	ScheduledTransferPending   = "pending"
+	ScheduledTransferExecuted  = "executed"
+	ScheduledTransferFailed    = "failed"
+	ScheduledTransferCancelled = "cancelled"
+)
+
+type ExecuteScheduledTransferTxResult struct {
+	ScheduledTransfer ScheduledTransfer `json:"scheduled_transfer"`
+	Transfer          TransferTxResult  `json:"transfer"`
+}
+
+// ExecuteScheduledTransferTx claims the oldest due scheduled transfer and executes it in the same transaction.
+// Rows claimed by other workers are skipped, so several workers can run side by side.
+// Transfers that cannot be made, e.g. because of insufficient funds or because the owner can no longer spend from
+// the account, are recorded as failed instead of returning an error.
+// sql.ErrNoRows is returned when nothing is due.
+func (store *SQLStore) ExecuteScheduledTransferTx(ctx context.Context) (ExecuteScheduledTransferTxResult, error) {
+	var result ExecuteScheduledTransferTxResult
+
+	err := store.execTx(ctx, store.transferIsolation(ctx), func(q *Queries) error {
+		scheduled, err := q.GetDueScheduledTransferForUpdate(ctx)
+		if err != nil {
+			return err
+		}
+		result.ScheduledTransfer = scheduled
+
+		arg := UpdateScheduledTransferResultParams{
+			ID:     scheduled.ID,
+			Status: ScheduledTransferExecuted,
+		}
+
+		result.Transfer, err = transferOnBehalf(ctx, q, scheduled.Owner, CreateTransferParams{
+			FromAccountID: scheduled.FromAccountID,
+			ToAccountID:   scheduled.ToAccountID,
+			Amount:        scheduled.Amount,
+		}, &store.options.TransferLimits)
+		switch {
+		case err == nil:
+			arg.TransferID = sql.NullInt64{Int64: result.Transfer.Transfer.ID, Valid: true}
+		case errors.Is(err, ErrInsufficientFunds), errors.Is(err, ErrTransferLimitExceeded), errors.Is(err, ErrAccountNotActive),
+			errors.Is(err, ErrNotAllowedToSpend), errors.Is(err, ErrCurrencyMismatch), errors.Is(err, sql.ErrNoRows):
+			// these are detected before anything is written, so the transaction is still usable
+			arg.Status = ScheduledTransferFailed
+			arg.FailureReason = err.Error()
+		default:
+			return err
+		}
+
+		result.ScheduledTransfer, err = q.UpdateScheduledTransferResult(ctx, arg)
+		return err
+	})
+
+	return result, err
+}
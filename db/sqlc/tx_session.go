@@ -0,0 +1,44 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+var ErrRefreshTokenReused = errors.New("refresh token was already used")
+
+// RotateSessionTxParams replaces the session of a refresh token with a new one of the same family
+type RotateSessionTxParams struct {
+	Session    Session             `json:"session"`
+	NewSession CreateSessionParams `json:"new_session"`
+}
+
+// RotateSessionTx marks the refresh token of a session as used and creates the session of the refresh token
+// replacing it. A refresh token can only be used once, when it was already used it was most likely stolen,
+// so every session of its family is blocked and ErrRefreshTokenReused is returned.
+func (store *SQLStore) RotateSessionTx(ctx context.Context, arg RotateSessionTxParams) (Session, error) {
+	var session Session
+
+	err := store.runTx(ctx, sql.LevelReadCommitted, func(q *Queries) error {
+		_, err := q.UseSession(ctx, arg.Session.ID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrRefreshTokenReused
+			}
+			return err
+		}
+
+		newSession := arg.NewSession
+		newSession.FamilyID = arg.Session.FamilyID
+		session, err = q.CreateSession(ctx, newSession)
+		return err
+	})
+	if errors.Is(err, ErrRefreshTokenReused) {
+		if blockErr := store.BlockSessionFamily(ctx, arg.Session.FamilyID); blockErr != nil {
+			return session, blockErr
+		}
+	}
+
+	return session, err
+}
@@ -0,0 +1,122 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/haniifac/simplebank/util"
+)
+
+const (
+	StandingOrderActive    = "active"
+	StandingOrderCompleted = "completed"
+	StandingOrderCancelled = "cancelled"
+
+	InsufficientFundsSkip  = "skip"
+	InsufficientFundsRetry = "retry"
+
+	StandingOrderExecutionSucceeded = "succeeded"
+	StandingOrderExecutionRetrying  = "retrying"
+	StandingOrderExecutionSkipped   = "skipped"
+)
+
+type ExecuteStandingOrderTxParams struct {
+	// RetryInterval is how long an order with the retry policy waits after insufficient funds
+	RetryInterval time.Duration
+}
+
+type ExecuteStandingOrderTxResult struct {
+	StandingOrder StandingOrder          `json:"standing_order"`
+	Execution     StandingOrderExecution `json:"execution"`
+	Transfer      TransferTxResult       `json:"transfer"`
+}
+
+// ExecuteStandingOrderTx claims the standing order that has been due the longest and runs its current occurrence.
+// Every attempt is recorded as an execution. When the account cannot cover the amount, the order either retries
+// after arg.RetryInterval or skips to its next occurrence, depending on its policy. A retry that would not happen
+// before the next occurrence is skipped instead. When the owner can no longer spend from the account, or the accounts
+// no longer share a currency, the occurrence is skipped and the order cancelled, as no later occurrence could succeed.
+// sql.ErrNoRows is returned when no order is due.
+func (store *SQLStore) ExecuteStandingOrderTx(ctx context.Context, arg ExecuteStandingOrderTxParams) (ExecuteStandingOrderTxResult, error) {
+	var result ExecuteStandingOrderTxResult
+
+	err := store.execTx(ctx, store.transferIsolation(ctx), func(q *Queries) error {
+		order, err := q.GetDueStandingOrderForUpdate(ctx)
+		if err != nil {
+			return err
+		}
+		result.StandingOrder = order
+
+		execution := CreateStandingOrderExecutionParams{
+			StandingOrderID: order.ID,
+			ScheduledFor:    order.NextRunAt,
+		}
+		schedule := UpdateStandingOrderScheduleParams{
+			ID:             order.ID,
+			NextRunAt:      order.NextRunAt,
+			RemainingCount: order.RemainingCount,
+			Status:         order.Status,
+		}
+		next := util.NextOccurrence(order.Frequency, int(order.DayOfMonth), order.NextRunAt)
+
+		result.Transfer, err = transferOnBehalf(ctx, q, order.Owner, CreateTransferParams{
+			FromAccountID: order.FromAccountID,
+			ToAccountID:   order.ToAccountID,
+			Amount:        order.Amount,
+		}, &store.options.TransferLimits)
+		switch {
+		case err == nil:
+			execution.Status = StandingOrderExecutionSucceeded
+			execution.TransferID = sql.NullInt64{Int64: result.Transfer.Transfer.ID, Valid: true}
+		case errors.Is(err, ErrNotAllowedToSpend), errors.Is(err, ErrCurrencyMismatch):
+			execution.FailureReason = err.Error()
+			execution.Status = StandingOrderExecutionSkipped
+			schedule.Status = StandingOrderCancelled
+		case errors.Is(err, ErrInsufficientFunds), errors.Is(err, ErrTransferLimitExceeded), errors.Is(err, ErrAccountNotActive), errors.Is(err, sql.ErrNoRows):
+			// these are detected before anything is written, so the transaction is still usable
+			execution.FailureReason = err.Error()
+			execution.Status = StandingOrderExecutionSkipped
+
+			retryAt := time.Now().Add(arg.RetryInterval)
+			if order.InsufficientFundsPolicy == InsufficientFundsRetry && arg.RetryInterval > 0 && retryAt.Before(next) {
+				execution.Status = StandingOrderExecutionRetrying
+				schedule.RetryAt = sql.NullTime{Time: retryAt, Valid: true}
+			}
+		default:
+			return err
+		}
+
+		if execution.Status != StandingOrderExecutionRetrying && schedule.Status != StandingOrderCancelled {
+			advanceStandingOrder(order, next, &schedule)
+		}
+
+		result.Execution, err = q.CreateStandingOrderExecution(ctx, execution)
+		if err != nil {
+			return err
+		}
+
+		result.StandingOrder, err = q.UpdateStandingOrderSchedule(ctx, schedule)
+		return err
+	})
+
+	return result, err
+}
+
+// advanceStandingOrder moves the schedule to the next occurrence and completes the order once it runs out
+func advanceStandingOrder(order StandingOrder, next time.Time, schedule *UpdateStandingOrderScheduleParams) {
+	schedule.NextRunAt = next
+	schedule.RetryAt = sql.NullTime{}
+
+	if order.RemainingCount.Valid {
+		schedule.RemainingCount.Int32--
+		if schedule.RemainingCount.Int32 <= 0 {
+			schedule.Status = StandingOrderCompleted
+		}
+	}
+
+	if order.EndDate.Valid && next.After(order.EndDate.Time) {
+		schedule.Status = StandingOrderCompleted
+	}
+}
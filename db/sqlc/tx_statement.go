@@ -0,0 +1,150 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+type AccountStatementTxParams struct {
+	AccountID int64     `json:"account_id"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+}
+
+// StatementLine is an entry of a statement with the account balance right after it
+type StatementLine struct {
+	EntryID     int64         `json:"entry_id"`
+	Amount      int64         `json:"amount"`
+	CreatedAt   time.Time     `json:"created_at"`
+	JournalID   sql.NullInt64 `json:"journal_id"`
+	Description string        `json:"description"`
+	Balance     int64         `json:"balance"`
+}
+
+type AccountStatementTxResult struct {
+	Account        Account   `json:"account"`
+	StartTime      time.Time `json:"start_time"`
+	EndTime        time.Time `json:"end_time"`
+	OpeningBalance int64     `json:"opening_balance"`
+	ClosingBalance int64     `json:"closing_balance"`
+	// OverdraftInUse is how far the closing balance is below zero
+	OverdraftInUse int64           `json:"overdraft_in_use"`
+	Lines          []StatementLine `json:"lines"`
+}
+
+// StatementWriter receives a statement line by line while it is read from the database
+type StatementWriter interface {
+	WriteHeader(account Account, arg AccountStatementTxParams, openingBalance int64) error
+	WriteLine(line StatementLine) error
+	WriteFooter(closingBalance int64) error
+}
+
+// AccountStatementTx lists the entries of an account in [StartTime, EndTime) with a running balance.
+// The opening balance is derived from the current account balance, and all reads share a single
+// repeatable read snapshot, so the statement adds up with accounts.balance while transfers are in flight.
+func (store *SQLStore) AccountStatementTx(ctx context.Context, arg AccountStatementTxParams) (AccountStatementTxResult, error) {
+	var result AccountStatementTxResult
+
+	err := store.execTx(ctx, sql.LevelRepeatableRead, func(q *Queries) error {
+		result = AccountStatementTxResult{Lines: []StatementLine{}}
+		return readStatement(ctx, q, arg, &statementCollector{result: &result})
+	})
+
+	return result, err
+}
+
+// StreamAccountStatementTx reads the same statement as AccountStatementTx but hands every line to w
+// as soon as it is read instead of keeping the statement in memory.
+// It is never retried because w may already have written part of the statement.
+func (store *SQLStore) StreamAccountStatementTx(ctx context.Context, arg AccountStatementTxParams, w StatementWriter) error {
+	return store.runTx(ctx, sql.LevelRepeatableRead, func(q *Queries) error {
+		return readStatement(ctx, q, arg, w)
+	})
+}
+
+func readStatement(ctx context.Context, q *Queries, arg AccountStatementTxParams, w StatementWriter) error {
+	account, err := q.GetAccount(ctx, arg.AccountID)
+	if err != nil {
+		return err
+	}
+
+	openingBalance, err := q.GetAccountBalanceAt(ctx, GetAccountBalanceAtParams{
+		At:        arg.StartTime,
+		AccountID: arg.AccountID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := w.WriteHeader(account, arg, openingBalance); err != nil {
+		return err
+	}
+
+	// the rows are scanned one by one instead of using ListStatementEntries, which loads them all
+	rows, err := q.db.QueryContext(ctx, listStatementEntries, arg.AccountID, arg.StartTime, arg.EndTime)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	closingBalance := openingBalance
+	for rows.Next() {
+		var i ListStatementEntriesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Amount,
+			&i.CreatedAt,
+			&i.JournalID,
+			&i.Description,
+			&i.RunningTotal,
+		); err != nil {
+			return err
+		}
+
+		line := StatementLine{
+			EntryID:     i.ID,
+			Amount:      i.Amount,
+			CreatedAt:   i.CreatedAt,
+			JournalID:   i.JournalID,
+			Description: i.Description.String,
+			Balance:     openingBalance + i.RunningTotal,
+		}
+		if err := w.WriteLine(line); err != nil {
+			return err
+		}
+		closingBalance = line.Balance
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	return w.WriteFooter(closingBalance)
+}
+
+// statementCollector keeps a statement in memory
+type statementCollector struct {
+	result *AccountStatementTxResult
+}
+
+func (c *statementCollector) WriteHeader(account Account, arg AccountStatementTxParams, openingBalance int64) error {
+	c.result.Account = account
+	c.result.StartTime = arg.StartTime
+	c.result.EndTime = arg.EndTime
+	c.result.OpeningBalance = openingBalance
+	return nil
+}
+
+func (c *statementCollector) WriteLine(line StatementLine) error {
+	c.result.Lines = append(c.result.Lines, line)
+	return nil
+}
+
+func (c *statementCollector) WriteFooter(closingBalance int64) error {
+	c.result.ClosingBalance = closingBalance
+	c.result.OverdraftInUse = OverdraftInUse(closingBalance)
+	return nil
+}
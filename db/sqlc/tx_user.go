@@ -0,0 +1,103 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+var (
+	ErrResetTokenInvalid        = errors.New("password reset token is invalid or expired")
+	ErrVerificationTokenInvalid = errors.New("email verification token is invalid or expired")
+)
+
+type ChangePasswordTxParams struct {
+	Username       string `json:"username"`
+	HashedPassword string `json:"hashed_password"`
+}
+
+// ChangePasswordTx stores the new password of a user and blocks all of their sessions,
+// so every device has to log in again with the new password
+func (store *SQLStore) ChangePasswordTx(ctx context.Context, arg ChangePasswordTxParams) (User, error) {
+	var user User
+
+	err := store.runTx(ctx, sql.LevelReadCommitted, func(q *Queries) error {
+		var err error
+		user, err = changePassword(ctx, q, arg)
+		return err
+	})
+
+	return user, err
+}
+
+// ResetPasswordTxParams sets the password of the user a reset token was sent to
+type ResetPasswordTxParams struct {
+	TokenHash      string `json:"token_hash"`
+	HashedPassword string `json:"hashed_password"`
+}
+
+// ResetPasswordTx uses up a password reset token and changes the password of its user like ChangePasswordTx.
+// The other outstanding reset tokens of the user are invalidated as well. ErrResetTokenInvalid is returned
+// when the token does not exist, was already used or has expired.
+func (store *SQLStore) ResetPasswordTx(ctx context.Context, arg ResetPasswordTxParams) (User, error) {
+	var user User
+
+	err := store.runTx(ctx, sql.LevelReadCommitted, func(q *Queries) error {
+		resetToken, err := q.UsePasswordResetToken(ctx, arg.TokenHash)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrResetTokenInvalid
+			}
+			return err
+		}
+
+		if err := q.InvalidatePasswordResetTokens(ctx, resetToken.Username); err != nil {
+			return err
+		}
+
+		user, err = changePassword(ctx, q, ChangePasswordTxParams{
+			Username:       resetToken.Username,
+			HashedPassword: arg.HashedPassword,
+		})
+		return err
+	})
+
+	return user, err
+}
+
+// VerifyEmailTx uses up an email verification token and marks the email of its user as verified.
+// ErrVerificationTokenInvalid is returned when the token does not exist, was already used, has expired
+// or was sent to an address the user no longer has.
+func (store *SQLStore) VerifyEmailTx(ctx context.Context, tokenHash string) (User, error) {
+	var user User
+
+	err := store.runTx(ctx, sql.LevelReadCommitted, func(q *Queries) error {
+		verification, err := q.UseEmailVerification(ctx, tokenHash)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrVerificationTokenInvalid
+			}
+			return err
+		}
+
+		user, err = q.VerifyUserEmail(ctx, VerifyUserEmailParams{
+			Username: verification.Username,
+			Email:    verification.Email,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrVerificationTokenInvalid
+		}
+		return err
+	})
+
+	return user, err
+}
+
+func changePassword(ctx context.Context, q *Queries, arg ChangePasswordTxParams) (User, error) {
+	user, err := q.UpdateUserPassword(ctx, UpdateUserPasswordParams(arg))
+	if err != nil {
+		return user, err
+	}
+
+	return user, q.BlockUserSessions(ctx, arg.Username)
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/haniifac/simplebank/util"
 	"github.com/stretchr/testify/require"
 )
@@ -27,6 +28,7 @@ func createRandomUser(t *testing.T) User {
 	require.Equal(t, arg.Email, user.Email)
 
 	require.True(t, user.PasswordUpdatedAt.IsZero())
+	require.False(t, user.IsEmailVerified)
 	require.NotZero(t, user.CreatedAt)
 
 	return user
@@ -51,3 +53,23 @@ func TestGetUser(t *testing.T) {
 	require.WithinDuration(t, expectedUser.CreatedAt, actualUser.CreatedAt, time.Second)
 	require.WithinDuration(t, expectedUser.PasswordUpdatedAt, actualUser.PasswordUpdatedAt, time.Second)
 }
+
+func TestChangePasswordTx(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+
+	session, err := testQueries.CreateSession(context.Background(), newSessionParams(user.Username, uuid.Nil))
+	require.NoError(t, err)
+
+	updated, err := store.ChangePasswordTx(context.Background(), ChangePasswordTxParams{
+		Username:       user.Username,
+		HashedPassword: util.RandomString(10),
+	})
+	require.NoError(t, err)
+	require.NotEqual(t, user.HashedPassword, updated.HashedPassword)
+	require.True(t, updated.PasswordUpdatedAt.After(user.PasswordUpdatedAt))
+
+	session, err = testQueries.GetSession(context.Background(), session.ID)
+	require.NoError(t, err)
+	require.True(t, session.IsBlocked)
+}
@@ -0,0 +1,97 @@
+package exchange
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LoadFile reads rates from a CSV or XML file, picked by its extension
+func LoadFile(path string) ([]Rate, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return ParseCSV(file)
+	case ".xml":
+		return ParseXML(file)
+	}
+	return nil, fmt.Errorf("unsupported exchange rate file %s: must be .csv or .xml", path)
+}
+
+// ParseCSV reads rates from rows of from_currency,to_currency,rate.
+// A header row starting with from_currency is skipped.
+func ParseCSV(r io.Reader) ([]Rate, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 3
+	reader.TrimLeadingSpace = true
+
+	var rates []Rate
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if line == 1 && strings.EqualFold(record[0], "from_currency") {
+			continue
+		}
+
+		rate, err := ParseRate(record[2])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		rates = append(rates, Rate{
+			From: strings.ToUpper(record[0]),
+			To:   strings.ToUpper(record[1]),
+			Rate: rate,
+		})
+	}
+
+	return rates, nil
+}
+
+// ecbEnvelope is the layout of the European Central Bank reference rate feed, where every rate is quoted against EUR
+type ecbEnvelope struct {
+	Cubes []struct {
+		Currency string `xml:"currency,attr"`
+		Rate     string `xml:"rate,attr"`
+	} `xml:"Cube>Cube>Cube"`
+}
+
+// ParseXML reads rates in the European Central Bank reference rate format
+func ParseXML(r io.Reader) ([]Rate, error) {
+	var envelope ecbEnvelope
+	if err := xml.NewDecoder(r).Decode(&envelope); err != nil {
+		return nil, err
+	}
+
+	rates := make([]Rate, 0, len(envelope.Cubes))
+	for _, cube := range envelope.Cubes {
+		rate, err := ParseRate(cube.Rate)
+		if err != nil {
+			return nil, fmt.Errorf("currency %s: %w", cube.Currency, err)
+		}
+
+		rates = append(rates, Rate{
+			From: "EUR",
+			To:   strings.ToUpper(cube.Currency),
+			Rate: rate,
+		})
+	}
+
+	return rates, nil
+}
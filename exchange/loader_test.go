@@ -0,0 +1,48 @@
+package exchange
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCSV(t *testing.T) {
+	input := "from_currency,to_currency,rate\nUSD,EUR,0.92\ncad, usd, 0.73\n"
+
+	rates, err := ParseCSV(strings.NewReader(input))
+	require.NoError(t, err)
+	require.Len(t, rates, 2)
+
+	require.Equal(t, "USD", rates[0].From)
+	require.Equal(t, "EUR", rates[0].To)
+	require.Equal(t, "0.9200000000", FormatRate(rates[0].Rate))
+
+	require.Equal(t, "CAD", rates[1].From)
+	require.Equal(t, "USD", rates[1].To)
+
+	_, err = ParseCSV(strings.NewReader("USD,EUR,-1\n"))
+	require.Error(t, err)
+}
+
+func TestParseXML(t *testing.T) {
+	input := `<?xml version="1.0" encoding="UTF-8"?>
+<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01" xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">
+	<gesmes:subject>Reference rates</gesmes:subject>
+	<Cube>
+		<Cube time="2026-10-16">
+			<Cube currency="USD" rate="1.0850"/>
+			<Cube currency="CAD" rate="1.4920"/>
+		</Cube>
+	</Cube>
+</gesmes:Envelope>`
+
+	rates, err := ParseXML(strings.NewReader(input))
+	require.NoError(t, err)
+	require.Len(t, rates, 2)
+
+	require.Equal(t, "EUR", rates[0].From)
+	require.Equal(t, "USD", rates[0].To)
+	require.Equal(t, "1.0850000000", FormatRate(rates[0].Rate))
+	require.Equal(t, "CAD", rates[1].To)
+}
@@ -0,0 +1,90 @@
+package exchange
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// RateScale is the number of decimals rates are stored with
+const RateScale = 10
+
+// Rate is the number of to currency units one from currency unit buys
+type Rate struct {
+	From string
+	To   string
+	Rate *big.Rat
+}
+
+// ParseRate parses a decimal rate such as the NUMERIC values stored in the database
+func ParseRate(rate string) (*big.Rat, error) {
+	r, ok := new(big.Rat).SetString(rate)
+	if !ok || r.Sign() <= 0 {
+		return nil, fmt.Errorf("invalid exchange rate %q", rate)
+	}
+	return r, nil
+}
+
+// FormatRate formats a rate with RateScale decimals
+func FormatRate(rate *big.Rat) string {
+	return rate.FloatString(RateScale)
+}
+
+// Convert converts an amount in minor units with the given rate.
+// The result is rounded down so the bank never credits more than it debits.
+func Convert(amount int64, rate string) (int64, error) {
+	r, err := ParseRate(rate)
+	if err != nil {
+		return 0, err
+	}
+
+	converted := new(big.Rat).Mul(new(big.Rat).SetInt64(amount), r)
+	result := new(big.Int).Quo(converted.Num(), converted.Denom())
+	if !result.IsInt64() {
+		return 0, fmt.Errorf("converted amount of %d overflows", amount)
+	}
+
+	return result.Int64(), nil
+}
+
+// Complete adds the inverse of every rate and the cross rates through a shared currency,
+// so a rate list quoted against a single base such as EUR covers every pair.
+// Rates that are given explicitly are never overwritten.
+func Complete(rates []Rate) []Rate {
+	pairs := make(map[[2]string]*big.Rat)
+	var order [][2]string
+
+	add := func(from, to string, rate *big.Rat) {
+		key := [2]string{from, to}
+		if from == to {
+			return
+		}
+		if _, ok := pairs[key]; ok {
+			return
+		}
+		pairs[key] = rate
+		order = append(order, key)
+	}
+
+	for _, rate := range rates {
+		add(rate.From, rate.To, rate.Rate)
+	}
+	for _, rate := range rates {
+		add(rate.To, rate.From, new(big.Rat).Inv(rate.Rate))
+	}
+
+	// cross rates: from -> base -> to
+	direct := append([][2]string(nil), order...)
+	for _, first := range direct {
+		for _, second := range direct {
+			if first[1] == second[0] {
+				add(first[0], second[1], new(big.Rat).Mul(pairs[first], pairs[second]))
+			}
+		}
+	}
+
+	result := make([]Rate, 0, len(order))
+	for _, key := range order {
+		result = append(result, Rate{From: key[0], To: key[1], Rate: pairs[key]})
+	}
+	return result
+}
@@ -0,0 +1,43 @@
+package exchange
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvert(t *testing.T) {
+	converted, err := Convert(10000, "1.0850000000")
+	require.NoError(t, err)
+	require.Equal(t, int64(10850), converted)
+
+	// fractions of a minor unit are rounded down
+	converted, err = Convert(999, "0.9216589862")
+	require.NoError(t, err)
+	require.Equal(t, int64(920), converted)
+
+	_, err = Convert(100, "abc")
+	require.Error(t, err)
+
+	_, err = Convert(100, "0")
+	require.Error(t, err)
+}
+
+func TestComplete(t *testing.T) {
+	rates := Complete([]Rate{
+		{From: "EUR", To: "USD", Rate: big.NewRat(108, 100)},
+		{From: "EUR", To: "CAD", Rate: big.NewRat(150, 100)},
+	})
+
+	found := make(map[[2]string]string)
+	for _, rate := range rates {
+		found[[2]string{rate.From, rate.To}] = FormatRate(rate.Rate)
+	}
+
+	require.Len(t, found, 6)
+	require.Equal(t, "1.0800000000", found[[2]string{"EUR", "USD"}])
+	require.Equal(t, "0.9259259259", found[[2]string{"USD", "EUR"}])
+	require.Equal(t, "1.3888888889", found[[2]string{"USD", "CAD"}])
+	require.Equal(t, "0.7200000000", found[[2]string{"CAD", "USD"}])
+}
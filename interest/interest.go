@@ -0,0 +1,94 @@
+package interest
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// Scale is the number of decimals accrued interest is stored with, in minor units
+const Scale = 18
+
+// DayCount is the convention that decides which fraction of the annual rate a single day earns
+type DayCount string
+
+const (
+	Actual365    DayCount = "ACT/365"
+	Actual360    DayCount = "ACT/360"
+	ActualActual DayCount = "ACT/ACT"
+)
+
+// ParseDayCount parses a day count convention, an empty string is ACT/365
+func ParseDayCount(s string) (DayCount, error) {
+	switch dc := DayCount(strings.ToUpper(s)); dc {
+	case "":
+		return Actual365, nil
+	case Actual365, Actual360, ActualActual:
+		return dc, nil
+	}
+	return "", fmt.Errorf("unknown day count convention %q: must be ACT/365, ACT/360 or ACT/ACT", s)
+}
+
+// daysInYear is the denominator of a single day under the convention
+func (dc DayCount) daysInYear(day time.Time) int64 {
+	switch dc {
+	case Actual360:
+		return 360
+	case ActualActual:
+		year := day.Year()
+		if year%4 == 0 && (year%100 != 0 || year%400 == 0) {
+			return 366
+		}
+	}
+	return 365
+}
+
+// ParseRate parses a decimal annual rate such as 0.02 for 2%
+func ParseRate(rate string) (*big.Rat, error) {
+	r, ok := new(big.Rat).SetString(rate)
+	if !ok || r.Sign() < 0 {
+		return nil, fmt.Errorf("invalid interest rate %q", rate)
+	}
+	return r, nil
+}
+
+// Daily is the interest a balance in minor units earns on the given day.
+// Negative balances earn nothing.
+func Daily(balance int64, rate *big.Rat, dc DayCount, day time.Time) *big.Rat {
+	if balance <= 0 {
+		return new(big.Rat)
+	}
+
+	amount := new(big.Rat).Mul(new(big.Rat).SetInt64(balance), rate)
+	return amount.Quo(amount, new(big.Rat).SetInt64(dc.daysInYear(day)))
+}
+
+// Format formats an amount with Scale decimals, truncating anything beyond them
+// so the stored accruals never add up to more than was earned
+func Format(amount *big.Rat) string {
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(Scale), nil)
+	scaled := new(big.Int).Mul(amount.Num(), scale)
+	scaled.Quo(scaled, amount.Denom())
+	return new(big.Rat).SetFrac(scaled, scale).FloatString(Scale)
+}
+
+// Parse parses an amount stored by Format
+func Parse(amount string) (*big.Rat, error) {
+	r, ok := new(big.Rat).SetString(amount)
+	if !ok {
+		return nil, fmt.Errorf("invalid interest amount %q", amount)
+	}
+	return r, nil
+}
+
+// Split splits an accrued amount into the whole minor units that can be posted and the fraction carried over
+func Split(accrued *big.Rat) (int64, *big.Rat, error) {
+	whole := new(big.Int).Quo(accrued.Num(), accrued.Denom())
+	if !whole.IsInt64() {
+		return 0, nil, fmt.Errorf("accrued interest %s overflows", accrued.FloatString(Scale))
+	}
+
+	carry := new(big.Rat).Sub(accrued, new(big.Rat).SetInt(whole))
+	return whole.Int64(), carry, nil
+}
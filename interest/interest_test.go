@@ -0,0 +1,72 @@
+package interest
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDaily(t *testing.T) {
+	rate, err := ParseRate("0.02")
+	require.NoError(t, err)
+
+	day := time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC)
+	leapDay := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	// 10000 * 0.02 / 365
+	require.Equal(t, big.NewRat(200, 365), Daily(10000, rate, Actual365, day))
+	require.Equal(t, big.NewRat(200, 360), Daily(10000, rate, Actual360, day))
+	require.Equal(t, big.NewRat(200, 365), Daily(10000, rate, ActualActual, day))
+	require.Equal(t, big.NewRat(200, 366), Daily(10000, rate, ActualActual, leapDay))
+
+	require.Zero(t, Daily(-10000, rate, Actual365, day).Sign())
+}
+
+func TestAccrualKeepsFractions(t *testing.T) {
+	rate, err := ParseRate("0.01")
+	require.NoError(t, err)
+
+	// 100 * 0.01 / 365 is far below a minor unit, but a year of it adds up to one
+	sum := new(big.Rat)
+	day := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 365; i++ {
+		stored, err := Parse(Format(Daily(100, rate, Actual365, day)))
+		require.NoError(t, err)
+		sum.Add(sum, stored)
+	}
+
+	amount, carry, err := Split(sum)
+	require.NoError(t, err)
+	// every stored accrual is truncated, so the year falls just short of a minor unit and is carried
+	require.Zero(t, amount)
+	require.Equal(t, "0.999999999999999900", Format(carry))
+
+	amount, carry, err = Split(new(big.Rat).Add(carry, big.NewRat(3, 2)))
+	require.NoError(t, err)
+	require.Equal(t, int64(2), amount)
+	require.Equal(t, "0.499999999999999900", Format(carry))
+}
+
+func TestFormat(t *testing.T) {
+	require.Equal(t, "0.333333333333333333", Format(big.NewRat(1, 3)))
+	require.Equal(t, "0.666666666666666666", Format(big.NewRat(2, 3)))
+	require.Equal(t, "12.000000000000000000", Format(big.NewRat(12, 1)))
+}
+
+func TestParseDayCount(t *testing.T) {
+	dc, err := ParseDayCount("act/360")
+	require.NoError(t, err)
+	require.Equal(t, Actual360, dc)
+
+	dc, err = ParseDayCount("")
+	require.NoError(t, err)
+	require.Equal(t, Actual365, dc)
+
+	_, err = ParseDayCount("30/360")
+	require.Error(t, err)
+
+	_, err = ParseRate("-0.01")
+	require.Error(t, err)
+}
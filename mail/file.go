@@ -0,0 +1,42 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// FileMailer writes every email to a .eml file instead of sending it, for development and tests
+type FileMailer struct {
+	dir  string
+	from string
+}
+
+func NewFileMailer(dir, from string) *FileMailer {
+	return &FileMailer{dir: dir, from: from}
+}
+
+func (mailer *FileMailer) Send(ctx context.Context, email Email) error {
+	if err := os.MkdirAll(mailer.dir, 0o700); err != nil {
+		return fmt.Errorf("cannot create mail directory: %w", err)
+	}
+
+	now := time.Now()
+	name := fmt.Sprintf("%s-%s.eml", now.UTC().Format("20060102T150405.000000000"), fileSafe(email.To))
+
+	return os.WriteFile(filepath.Join(mailer.dir, name), message(mailer.from, email, now), 0o600)
+}
+
+// fileSafe replaces the characters of an address that do not belong in a file name
+func fileSafe(address string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-', r == '@':
+			return r
+		}
+		return '_'
+	}, address)
+}
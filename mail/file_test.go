@@ -0,0 +1,34 @@
+package mail
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileMailer(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mail")
+	mailer := NewFileMailer(dir, "bank@example.com")
+
+	err := mailer.Send(context.Background(), Email{
+		To:      "user/1@example.com",
+		Subject: "Hello",
+		Body:    "first line\nsecond line",
+	})
+	require.NoError(t, err)
+
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	require.Contains(t, files[0].Name(), "user_1@example.com")
+
+	data, err := os.ReadFile(filepath.Join(dir, files[0].Name()))
+	require.NoError(t, err)
+	require.Contains(t, string(data), "From: bank@example.com\r\n")
+	require.Contains(t, string(data), "To: user/1@example.com\r\n")
+	require.Contains(t, string(data), "Subject: Hello\r\n")
+	require.Contains(t, string(data), "\r\n\r\nfirst line\r\nsecond line")
+}
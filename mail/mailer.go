@@ -0,0 +1,53 @@
+// Package mail sends emails to the users of the bank
+package mail
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/haniifac/simplebank/util"
+)
+
+// Email is a plain text email to a single recipient
+type Email struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer delivers emails, the implementation is picked from the config
+type Mailer interface {
+	Send(ctx context.Context, email Email) error
+}
+
+// NewMailer sends emails through SMTP when an SMTP server is configured,
+// otherwise they are written to files in the mail directory
+func NewMailer(config util.Config) Mailer {
+	if config.SMTPAddress != "" {
+		return NewSMTPMailer(config.SMTPAddress, config.SMTPUsername, config.SMTPPassword, config.MailFrom)
+	}
+
+	dir := config.MailDir
+	if dir == "" {
+		dir = filepath.Join(os.TempDir(), "simplebank-mail")
+	}
+	return NewFileMailer(dir, config.MailFrom)
+}
+
+// message renders an email in the internet message format
+func message(from string, email Email, date time.Time) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", email.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", email.Subject)
+	fmt.Fprintf(&b, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(email.Body, "\n", "\r\n"))
+	return []byte(b.String())
+}
@@ -0,0 +1,99 @@
+package mail
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/smtp"
+	"time"
+)
+
+// SMTPMailer sends emails through an SMTP server, authenticating with PLAIN auth when a username is set
+type SMTPMailer struct {
+	address string
+	host    string
+	from    string
+	auth    smtp.Auth
+}
+
+func NewSMTPMailer(address, username, password, from string) *SMTPMailer {
+	host, _, _ := net.SplitHostPort(address)
+	mailer := &SMTPMailer{address: address, host: host, from: from}
+	if username != "" {
+		mailer.auth = smtp.PlainAuth("", username, password, host)
+	}
+	return mailer
+}
+
+// Send delivers the email the way smtp.SendMail does, but gives up once ctx is done:
+// the connection's deadline is the one of ctx and the connection is closed when ctx is cancelled
+func (mailer *SMTPMailer) Send(ctx context.Context, email Email) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", mailer.address)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return err
+		}
+	}
+	stop := context.AfterFunc(ctx, func() {
+		conn.Close()
+	})
+	defer stop()
+
+	client, err := smtp.NewClient(conn, mailer.host)
+	if err != nil {
+		return contextError(ctx, err)
+	}
+	defer client.Close()
+
+	if err := mailer.send(client, email); err != nil {
+		return contextError(ctx, err)
+	}
+	return nil
+}
+
+func (mailer *SMTPMailer) send(client *smtp.Client, email Email) error {
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: mailer.host}); err != nil {
+			return err
+		}
+	}
+	if mailer.auth != nil {
+		if err := client.Auth(mailer.auth); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(mailer.from); err != nil {
+		return err
+	}
+	if err := client.Rcpt(email.To); err != nil {
+		return err
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(message(mailer.from, email, time.Now())); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+// contextError reports why ctx ended instead of the network error closing the connection caused
+func contextError(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
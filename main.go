@@ -1,12 +1,17 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"log"
+	"os"
 
 	"github.com/haniifac/simplebank/api"
 	db "github.com/haniifac/simplebank/db/sqlc"
+	"github.com/haniifac/simplebank/interest"
+	"github.com/haniifac/simplebank/reconcile"
 	"github.com/haniifac/simplebank/util"
+	"github.com/haniifac/simplebank/worker"
 	_ "github.com/lib/pq"
 )
 
@@ -21,7 +26,65 @@ func main() {
 		log.Fatal("cannot connect to db: ", err)
 	}
 
-	store := db.NewStore(conn)
+	txOptions, err := newTxOptions(config)
+	if err != nil {
+		log.Fatal("invalid transaction config: ", err)
+	}
+
+	store := db.NewStoreWithOptions(conn, txOptions)
+
+	if len(os.Args) > 1 {
+		if err := runCommand(config, store, os.Args[1:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if config.ScheduledTransferInterval > 0 {
+		processor := worker.NewScheduledTransferProcessor(store, config.ScheduledTransferInterval)
+		go processor.Start(context.Background())
+	}
+
+	if config.StandingOrderInterval > 0 {
+		processor := worker.NewStandingOrderProcessor(store, config.StandingOrderInterval, config.StandingOrderRetryInterval)
+		go processor.Start(context.Background())
+	}
+
+	if config.HoldSweepInterval > 0 {
+		sweeper := worker.NewHoldSweeper(store, config.HoldSweepInterval)
+		go sweeper.Start(context.Background())
+	}
+
+	if config.InterestInterval > 0 {
+		rate, err := interest.ParseRate(config.InterestRate)
+		if err != nil {
+			log.Fatal("invalid interest config: ", err)
+		}
+		dayCount, err := interest.ParseDayCount(config.InterestDayCount)
+		if err != nil {
+			log.Fatal("invalid interest config: ", err)
+		}
+
+		processor := worker.NewInterestProcessor(store, config.InterestInterval, rate, dayCount)
+		go processor.Start(context.Background())
+	}
+
+	if config.FeeInterval > 0 {
+		processor := worker.NewFeeProcessor(store, config.FeeInterval)
+		go processor.Start(context.Background())
+	}
+
+	if config.ReconcileInterval > 0 {
+		reconciler := reconcile.NewReconciler(store, config.ReconcileBatchSize)
+		processor := worker.NewReconcileProcessor(store, reconciler, config.ReconcileInterval)
+		go processor.Start(context.Background())
+	}
+
+	if config.BalanceSnapshotInterval > 0 {
+		processor := worker.NewBalanceSnapshotProcessor(store, config.BalanceSnapshotInterval)
+		go processor.Start(context.Background())
+	}
+
 	server, err := api.NewServer(config, store)
 	if err != nil {
 		log.Fatal("cannot create server: ", err)
@@ -33,3 +96,31 @@ func main() {
 	}
 
 }
+
+// newTxOptions overrides the default transaction options with the configured values
+func newTxOptions(config util.Config) (db.TxOptions, error) {
+	options := db.DefaultTxOptions()
+
+	isolation, err := db.ParseIsolationLevel(config.TxIsolationLevel)
+	if err != nil {
+		return options, err
+	}
+	options.TransferIsolation = isolation
+
+	if config.TxMaxAttempts > 0 {
+		options.MaxAttempts = config.TxMaxAttempts
+	}
+	if config.TxRetryBaseDelay > 0 {
+		options.BaseDelay = config.TxRetryBaseDelay
+	}
+	if config.TxRetryMaxDelay > 0 {
+		options.MaxDelay = config.TxRetryMaxDelay
+	}
+
+	options.TransferLimits = db.TransferLimits{
+		Daily:   config.DailyTransferLimit,
+		Monthly: config.MonthlyTransferLimit,
+	}
+
+	return options, nil
+}
@@ -0,0 +1,260 @@
+package reconcile
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	db "github.com/haniifac/simplebank/db/sqlc"
+)
+
+// DefaultBatchSize is how many rows are checked at once when no batch size is configured
+const DefaultBatchSize = 500
+
+// Report is the outcome of a reconciliation run
+type Report struct {
+	StartedAt          time.Time           `json:"started_at"`
+	FinishedAt         time.Time           `json:"finished_at"`
+	OK                 bool                `json:"ok"`
+	AccountsChecked    int64               `json:"accounts_checked"`
+	TransfersChecked   int64               `json:"transfers_checked"`
+	JournalsChecked    int64               `json:"journals_checked"`
+	BalanceMismatches  []BalanceMismatch   `json:"balance_mismatches"`
+	TransferMismatches []TransferMismatch  `json:"transfer_mismatches"`
+	UnbalancedJournals []UnbalancedJournal `json:"unbalanced_journals"`
+	OrphanedEntries    []db.Entry          `json:"orphaned_entries"`
+}
+
+// BalanceMismatch is an account whose balance is not the sum of its entries
+type BalanceMismatch struct {
+	AccountID    int64 `json:"account_id"`
+	Balance      int64 `json:"balance"`
+	EntriesTotal int64 `json:"entries_total"`
+	Difference   int64 `json:"difference"`
+}
+
+// TransferMismatch is a transfer that is not booked with exactly one debit and one credit entry
+type TransferMismatch struct {
+	TransferID int64    `json:"transfer_id"`
+	JournalID  int64    `json:"journal_id,omitempty"`
+	Problems   []string `json:"problems"`
+}
+
+// UnbalancedJournal is a journal whose entries in a currency do not sum to zero
+type UnbalancedJournal struct {
+	JournalID int64  `json:"journal_id"`
+	Currency  string `json:"currency"`
+	Total     int64  `json:"total"`
+}
+
+// Reconciler checks the ledger for inconsistencies
+type Reconciler struct {
+	store     db.Store
+	batchSize int32
+}
+
+func NewReconciler(store db.Store, batchSize int32) *Reconciler {
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+	return &Reconciler{
+		store:     store,
+		batchSize: batchSize,
+	}
+}
+
+// Run scans all accounts, transfers and entries in batches and reports every inconsistency found.
+// The rows are read outside of a transaction, so transfers committed while the run is in
+// progress can show up as mismatches that are gone on the next run.
+func (reconciler *Reconciler) Run(ctx context.Context) (Report, error) {
+	report := Report{
+		StartedAt:          time.Now(),
+		BalanceMismatches:  []BalanceMismatch{},
+		TransferMismatches: []TransferMismatch{},
+		UnbalancedJournals: []UnbalancedJournal{},
+		OrphanedEntries:    []db.Entry{},
+	}
+
+	if err := reconciler.checkBalances(ctx, &report); err != nil {
+		return report, err
+	}
+	if err := reconciler.checkTransfers(ctx, &report); err != nil {
+		return report, err
+	}
+	if err := reconciler.checkJournals(ctx, &report); err != nil {
+		return report, err
+	}
+	if err := reconciler.checkOrphanedEntries(ctx, &report); err != nil {
+		return report, err
+	}
+
+	report.FinishedAt = time.Now()
+	report.OK = len(report.BalanceMismatches) == 0 &&
+		len(report.TransferMismatches) == 0 &&
+		len(report.UnbalancedJournals) == 0 &&
+		len(report.OrphanedEntries) == 0
+	return report, nil
+}
+
+// Save stores the report so that it can be looked up later
+func Save(ctx context.Context, store db.Store, report Report) (db.ReconciliationReport, error) {
+	body, err := json.Marshal(report)
+	if err != nil {
+		return db.ReconciliationReport{}, err
+	}
+
+	return store.CreateReconciliationReport(ctx, db.CreateReconciliationReportParams{
+		StartedAt:  report.StartedAt,
+		FinishedAt: report.FinishedAt,
+		Ok:         report.OK,
+		Report:     body,
+	})
+}
+
+func (reconciler *Reconciler) checkBalances(ctx context.Context, report *Report) error {
+	var afterID int64
+	for {
+		rows, err := reconciler.store.ListAccountEntryTotals(ctx, db.ListAccountEntryTotalsParams{
+			AfterID: afterID,
+			Limit:   reconciler.batchSize,
+		})
+		if err != nil {
+			return fmt.Errorf("cannot list account entry totals: %w", err)
+		}
+
+		for _, row := range rows {
+			report.AccountsChecked++
+			if row.Balance != row.EntriesTotal {
+				report.BalanceMismatches = append(report.BalanceMismatches, BalanceMismatch{
+					AccountID:    row.ID,
+					Balance:      row.Balance,
+					EntriesTotal: row.EntriesTotal,
+					Difference:   row.Balance - row.EntriesTotal,
+				})
+			}
+		}
+
+		if len(rows) < int(reconciler.batchSize) {
+			return nil
+		}
+		afterID = rows[len(rows)-1].ID
+	}
+}
+
+func (reconciler *Reconciler) checkTransfers(ctx context.Context, report *Report) error {
+	var afterID int64
+	for {
+		rows, err := reconciler.store.ListTransferEntryTotals(ctx, db.ListTransferEntryTotalsParams{
+			AfterID: afterID,
+			Limit:   reconciler.batchSize,
+		})
+		if err != nil {
+			return fmt.Errorf("cannot list transfer entry totals: %w", err)
+		}
+
+		for _, row := range rows {
+			report.TransfersChecked++
+			if problems := transferProblems(row); len(problems) > 0 {
+				report.TransferMismatches = append(report.TransferMismatches, TransferMismatch{
+					TransferID: row.ID,
+					JournalID:  row.JournalID.Int64,
+					Problems:   problems,
+				})
+			}
+		}
+
+		if len(rows) < int(reconciler.batchSize) {
+			return nil
+		}
+		afterID = rows[len(rows)-1].ID
+	}
+}
+
+// transferProblems describes how the entries of a transfer's journal differ from a single
+// debit of the amount on the source and a single credit of the converted amount on the destination
+func transferProblems(row db.ListTransferEntryTotalsRow) []string {
+	if !row.JournalID.Valid {
+		return []string{"transfer has no journal"}
+	}
+
+	credit := row.Amount
+	if row.ToAmount.Valid {
+		credit = row.ToAmount.Int64
+	}
+
+	var problems []string
+	if row.Debits != 1 {
+		problems = append(problems, fmt.Sprintf("%d debit entries on account %d, want 1", row.Debits, row.FromAccountID))
+	} else if row.DebitTotal != -row.Amount {
+		problems = append(problems, fmt.Sprintf("debit of %d on account %d, want %d", -row.DebitTotal, row.FromAccountID, row.Amount))
+	}
+	if row.Credits != 1 {
+		problems = append(problems, fmt.Sprintf("%d credit entries on account %d, want 1", row.Credits, row.ToAccountID))
+	} else if row.CreditTotal != credit {
+		problems = append(problems, fmt.Sprintf("credit of %d on account %d, want %d", row.CreditTotal, row.ToAccountID, credit))
+	}
+	if other := row.Entries - row.Debits - row.Credits; other > 0 {
+		problems = append(problems, fmt.Sprintf("%d unexpected entries", other))
+	}
+	return problems
+}
+
+// checkJournals reports the journals whose entries do not sum to zero per currency. The journals of cross currency
+// transfers are the exception: they debit the sender in one currency and credit the receiver in another, without
+// a bank account taking the other side of the exchange, so checkTransfers checks their two entries instead.
+func (reconciler *Reconciler) checkJournals(ctx context.Context, report *Report) error {
+	var afterID int64
+	for {
+		rows, err := reconciler.store.ListJournalCurrencyTotals(ctx, db.ListJournalCurrencyTotalsParams{
+			AfterID: afterID,
+			Limit:   reconciler.batchSize,
+		})
+		if err != nil {
+			return fmt.Errorf("cannot list journal currency totals: %w", err)
+		}
+
+		// a journal has a row per currency, so the batch is full when it has batchSize distinct journals
+		journals := 0
+		for _, row := range rows {
+			if row.JournalID != afterID {
+				journals++
+				afterID = row.JournalID
+			}
+
+			if row.Exchange || !row.Currency.Valid || row.Total == 0 {
+				continue
+			}
+			report.UnbalancedJournals = append(report.UnbalancedJournals, UnbalancedJournal{
+				JournalID: row.JournalID,
+				Currency:  row.Currency.String,
+				Total:     row.Total,
+			})
+		}
+		report.JournalsChecked += int64(journals)
+
+		if journals < int(reconciler.batchSize) {
+			return nil
+		}
+	}
+}
+
+func (reconciler *Reconciler) checkOrphanedEntries(ctx context.Context, report *Report) error {
+	var afterID int64
+	for {
+		entries, err := reconciler.store.ListOrphanedEntries(ctx, db.ListOrphanedEntriesParams{
+			AfterID: afterID,
+			Limit:   reconciler.batchSize,
+		})
+		if err != nil {
+			return fmt.Errorf("cannot list orphaned entries: %w", err)
+		}
+
+		report.OrphanedEntries = append(report.OrphanedEntries, entries...)
+
+		if len(entries) < int(reconciler.batchSize) {
+			return nil
+		}
+		afterID = entries[len(entries)-1].ID
+	}
+}
@@ -0,0 +1,158 @@
+package reconcile
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+
+	mockdb "github.com/haniifac/simplebank/db/mock"
+	db "github.com/haniifac/simplebank/db/sqlc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func journal(id int64) sql.NullInt64 {
+	return sql.NullInt64{Int64: id, Valid: true}
+}
+
+func TestRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+
+	// a full batch is followed by another one that starts after its last row
+	store.EXPECT().
+		ListAccountEntryTotals(gomock.Any(), gomock.Eq(db.ListAccountEntryTotalsParams{AfterID: 0, Limit: 2})).
+		Times(1).
+		Return([]db.ListAccountEntryTotalsRow{
+			{ID: 1, Balance: 100, EntriesTotal: 100},
+			{ID: 2, Balance: 50, EntriesTotal: 40},
+		}, nil)
+	store.EXPECT().
+		ListAccountEntryTotals(gomock.Any(), gomock.Eq(db.ListAccountEntryTotalsParams{AfterID: 2, Limit: 2})).
+		Times(1).
+		Return([]db.ListAccountEntryTotalsRow{{ID: 3, Balance: 0, EntriesTotal: 0}}, nil)
+
+	store.EXPECT().
+		ListTransferEntryTotals(gomock.Any(), gomock.Eq(db.ListTransferEntryTotalsParams{AfterID: 0, Limit: 2})).
+		Times(1).
+		Return([]db.ListTransferEntryTotalsRow{
+			{ID: 1, FromAccountID: 1, ToAccountID: 2, Amount: 10, JournalID: journal(1), Debits: 1, DebitTotal: -10, Credits: 1, CreditTotal: 10, Entries: 2},
+			{ID: 2, FromAccountID: 1, ToAccountID: 3, Amount: 10, ToAmount: sql.NullInt64{Int64: 9, Valid: true}, JournalID: journal(2), Debits: 1, DebitTotal: -10, Credits: 1, CreditTotal: 9, Entries: 2},
+		}, nil)
+	store.EXPECT().
+		ListTransferEntryTotals(gomock.Any(), gomock.Eq(db.ListTransferEntryTotalsParams{AfterID: 2, Limit: 2})).
+		Times(1).
+		Return([]db.ListTransferEntryTotalsRow{
+			{ID: 3, FromAccountID: 2, ToAccountID: 1, Amount: 5, JournalID: journal(3), Debits: 1, DebitTotal: -5, Credits: 0, Entries: 1},
+		}, nil)
+
+	usd := sql.NullString{String: "USD", Valid: true}
+	eur := sql.NullString{String: "EUR", Valid: true}
+
+	// journals with rows in several currencies count once towards the batch size
+	store.EXPECT().
+		ListJournalCurrencyTotals(gomock.Any(), gomock.Eq(db.ListJournalCurrencyTotalsParams{AfterID: 0, Limit: 2})).
+		Times(1).
+		Return([]db.ListJournalCurrencyTotalsRow{
+			{JournalID: 1, Currency: usd},
+			{JournalID: 2, Currency: eur, Exchange: true, Total: 9},
+			{JournalID: 2, Currency: usd, Exchange: true, Total: -10},
+		}, nil)
+	store.EXPECT().
+		ListJournalCurrencyTotals(gomock.Any(), gomock.Eq(db.ListJournalCurrencyTotalsParams{AfterID: 2, Limit: 2})).
+		Times(1).
+		Return([]db.ListJournalCurrencyTotalsRow{
+			{JournalID: 3, Currency: usd, Total: -5},
+		}, nil)
+
+	orphan := db.Entry{ID: 7, AccountID: 2, Amount: 10}
+	store.EXPECT().
+		ListOrphanedEntries(gomock.Any(), gomock.Eq(db.ListOrphanedEntriesParams{AfterID: 0, Limit: 2})).
+		Times(1).
+		Return([]db.Entry{orphan}, nil)
+
+	report, err := NewReconciler(store, 2).Run(context.Background())
+	require.NoError(t, err)
+	require.False(t, report.OK)
+	require.EqualValues(t, 3, report.AccountsChecked)
+	require.EqualValues(t, 3, report.TransfersChecked)
+	require.EqualValues(t, 3, report.JournalsChecked)
+	require.Equal(t, []BalanceMismatch{{AccountID: 2, Balance: 50, EntriesTotal: 40, Difference: 10}}, report.BalanceMismatches)
+	require.Equal(t, []TransferMismatch{{TransferID: 3, JournalID: 3, Problems: []string{"0 credit entries on account 1, want 1"}}}, report.TransferMismatches)
+	require.Equal(t, []UnbalancedJournal{{JournalID: 3, Currency: "USD", Total: -5}}, report.UnbalancedJournals)
+	require.Equal(t, []db.Entry{orphan}, report.OrphanedEntries)
+	require.False(t, report.FinishedAt.Before(report.StartedAt))
+}
+
+func TestRunBalanced(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().ListAccountEntryTotals(gomock.Any(), gomock.Any()).Times(1).Return(nil, nil)
+	store.EXPECT().ListTransferEntryTotals(gomock.Any(), gomock.Any()).Times(1).Return(nil, nil)
+	store.EXPECT().ListJournalCurrencyTotals(gomock.Any(), gomock.Any()).Times(1).Return(nil, nil)
+	store.EXPECT().ListOrphanedEntries(gomock.Any(), gomock.Any()).Times(1).Return(nil, nil)
+
+	report, err := NewReconciler(store, 0).Run(context.Background())
+	require.NoError(t, err)
+	require.True(t, report.OK)
+
+	// empty lists are reported as such rather than as null
+	body, err := json.Marshal(report)
+	require.NoError(t, err)
+	require.Contains(t, string(body), `"balance_mismatches":[]`)
+	require.Contains(t, string(body), `"unbalanced_journals":[]`)
+	require.Contains(t, string(body), `"orphaned_entries":[]`)
+}
+
+func TestRunError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().ListAccountEntryTotals(gomock.Any(), gomock.Any()).Times(1).Return(nil, sql.ErrConnDone)
+	store.EXPECT().ListTransferEntryTotals(gomock.Any(), gomock.Any()).Times(0)
+
+	_, err := NewReconciler(store, 10).Run(context.Background())
+	require.ErrorIs(t, err, sql.ErrConnDone)
+}
+
+func TestTransferProblems(t *testing.T) {
+	testCases := []struct {
+		name     string
+		row      db.ListTransferEntryTotalsRow
+		problems []string
+	}{
+		{
+			name:     "NoJournal",
+			row:      db.ListTransferEntryTotalsRow{ID: 1, Amount: 10},
+			problems: []string{"transfer has no journal"},
+		},
+		{
+			name: "WrongAmounts",
+			row: db.ListTransferEntryTotalsRow{
+				FromAccountID: 1, ToAccountID: 2, Amount: 10, JournalID: journal(1),
+				Debits: 1, DebitTotal: -8, Credits: 1, CreditTotal: 12, Entries: 2,
+			},
+			problems: []string{"debit of 8 on account 1, want 10", "credit of 12 on account 2, want 10"},
+		},
+		{
+			name: "DuplicateDebitAndExtraEntry",
+			row: db.ListTransferEntryTotalsRow{
+				FromAccountID: 1, ToAccountID: 2, Amount: 10, JournalID: journal(1),
+				Debits: 2, DebitTotal: -20, Credits: 1, CreditTotal: 10, Entries: 4,
+			},
+			problems: []string{"2 debit entries on account 1, want 1", "1 unexpected entries"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.problems, transferProblems(tc.row))
+		})
+	}
+}
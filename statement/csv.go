@@ -0,0 +1,44 @@
+package statement
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+
+	db "github.com/haniifac/simplebank/db/sqlc"
+)
+
+// csvWriter writes one row per entry. Opening and closing balances follow from the balance column.
+type csvWriter struct {
+	w        *csv.Writer
+	number   string
+	currency string
+}
+
+func NewCSVWriter(w io.Writer) db.StatementWriter {
+	return &csvWriter{w: csv.NewWriter(w)}
+}
+
+func (c *csvWriter) WriteHeader(account db.Account, arg db.AccountStatementTxParams, openingBalance int64) error {
+	c.number = account.Number
+	c.currency = account.Currency
+	return c.w.Write([]string{"account_number", "entry_id", "date", "description", "amount", "currency", "balance"})
+}
+
+func (c *csvWriter) WriteLine(line db.StatementLine) error {
+	return c.w.Write([]string{
+		c.number,
+		strconv.FormatInt(line.EntryID, 10),
+		line.CreatedAt.UTC().Format(time.RFC3339),
+		line.Description,
+		formatAmount(line.Amount, c.currency, "."),
+		c.currency,
+		formatAmount(line.Balance, c.currency, "."),
+	})
+}
+
+func (c *csvWriter) WriteFooter(closingBalance int64) error {
+	c.w.Flush()
+	return c.w.Error()
+}
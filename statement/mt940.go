@@ -0,0 +1,105 @@
+package statement
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	db "github.com/haniifac/simplebank/db/sqlc"
+)
+
+const mt940Date = "060102"
+
+// mt940Writer writes a SWIFT MT940 customer statement message body
+type mt940Writer struct {
+	w        *bufio.Writer
+	currency string
+	endTime  time.Time
+}
+
+func NewMT940Writer(w io.Writer) db.StatementWriter {
+	return &mt940Writer{w: bufio.NewWriter(w)}
+}
+
+func (m *mt940Writer) WriteHeader(account db.Account, arg db.AccountStatementTxParams, openingBalance int64) error {
+	m.currency = account.Currency
+	m.endTime = arg.EndTime
+
+	reference := fmt.Sprintf("STMT%d%s", account.ID, arg.EndTime.UTC().Format(mt940Date))
+	if len(reference) > 16 {
+		reference = reference[len(reference)-16:]
+	}
+
+	return m.lines(
+		":20:"+reference,
+		":25:"+account.Number,
+		":28C:1/1",
+		":60F:"+m.balance(openingBalance, arg.StartTime),
+	)
+}
+
+func (m *mt940Writer) WriteLine(line db.StatementLine) error {
+	mark, amount := "C", line.Amount
+	if amount < 0 {
+		mark, amount = "D", -amount
+	}
+
+	date := line.CreatedAt.UTC()
+	description := mt940Text(line.Description)
+	if description == "" {
+		description = fmt.Sprintf("ENTRY %d", line.EntryID)
+	}
+
+	return m.lines(
+		fmt.Sprintf(":61:%s%s%s%sNTRFNONREF//%d", date.Format(mt940Date), date.Format("0102"), mark, formatAmount(amount, m.currency, ","), line.EntryID),
+		":86:"+description,
+	)
+}
+
+func (m *mt940Writer) WriteFooter(closingBalance int64) error {
+	// the closing balance is dated with the last day the statement covers
+	if err := m.lines(":62F:"+m.balance(closingBalance, m.endTime.Add(-time.Nanosecond)), "-"); err != nil {
+		return err
+	}
+
+	return m.w.Flush()
+}
+
+// balance formats a balance field such as C240131USD70,00
+func (m *mt940Writer) balance(amount int64, date time.Time) string {
+	mark := "C"
+	if amount < 0 {
+		mark, amount = "D", -amount
+	}
+	return mark + date.UTC().Format(mt940Date) + m.currency + formatAmount(amount, m.currency, ",")
+}
+
+// lines writes the fields with the CRLF line endings of SWIFT messages
+func (m *mt940Writer) lines(fields ...string) error {
+	for _, field := range fields {
+		if _, err := m.w.WriteString(field + "\r\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mt940Text keeps a description within the SWIFT x character set and the 65 characters of a :86: line
+func mt940Text(s string) string {
+	s = strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		case strings.ContainsRune("/-?:().,'+ ", r):
+			return r
+		}
+		return ' '
+	}, s)
+
+	if len(s) > 65 {
+		s = s[:65]
+	}
+	return s
+}
@@ -0,0 +1,136 @@
+package statement
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	db "github.com/haniifac/simplebank/db/sqlc"
+)
+
+const (
+	ofxBankID   = "SIMPLEBANK"
+	ofxDateTime = "20060102150405"
+)
+
+var ofxEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// ofxWriter writes an OFX 1.02 (SGML) bank statement response
+type ofxWriter struct {
+	w        *bufio.Writer
+	currency string
+	endTime  time.Time
+}
+
+func NewOFXWriter(w io.Writer) db.StatementWriter {
+	return &ofxWriter{w: bufio.NewWriter(w)}
+}
+
+func (o *ofxWriter) WriteHeader(account db.Account, arg db.AccountStatementTxParams, openingBalance int64) error {
+	o.currency = account.Currency
+	o.endTime = arg.EndTime
+
+	_, err := fmt.Fprintf(o.w, `OFXHEADER:100
+DATA:OFXSGML
+VERSION:102
+SECURITY:NONE
+ENCODING:USASCII
+CHARSET:1252
+COMPRESSION:NONE
+OLDFILEUID:NONE
+NEWFILEUID:NONE
+
+<OFX>
+<SIGNONMSGSRSV1>
+<SONRS>
+<STATUS>
+<CODE>0
+<SEVERITY>INFO
+</STATUS>
+<DTSERVER>%s
+<LANGUAGE>ENG
+</SONRS>
+</SIGNONMSGSRSV1>
+<BANKMSGSRSV1>
+<STMTTRNRS>
+<TRNUID>%d
+<STATUS>
+<CODE>0
+<SEVERITY>INFO
+</STATUS>
+<STMTRS>
+<CURDEF>%s
+<BANKACCTFROM>
+<BANKID>%s
+<ACCTID>%s
+<ACCTTYPE>CHECKING
+</BANKACCTFROM>
+<BANKTRANLIST>
+<DTSTART>%s
+<DTEND>%s
+`,
+		time.Now().UTC().Format(ofxDateTime),
+		account.ID,
+		account.Currency,
+		ofxBankID,
+		account.Number,
+		arg.StartTime.UTC().Format(ofxDateTime),
+		arg.EndTime.UTC().Format(ofxDateTime),
+	)
+	return err
+}
+
+func (o *ofxWriter) WriteLine(line db.StatementLine) error {
+	trnType := "CREDIT"
+	if line.Amount < 0 {
+		trnType = "DEBIT"
+	}
+
+	name := line.Description
+	if name == "" {
+		name = fmt.Sprintf("entry %d", line.EntryID)
+	}
+	// NAME is limited to 32 characters
+	if len(name) > 32 {
+		name = name[:32]
+	}
+
+	_, err := fmt.Fprintf(o.w, `<STMTTRN>
+<TRNTYPE>%s
+<DTPOSTED>%s
+<TRNAMT>%s
+<FITID>%d
+<NAME>%s
+</STMTTRN>
+`,
+		trnType,
+		line.CreatedAt.UTC().Format(ofxDateTime),
+		formatAmount(line.Amount, o.currency, "."),
+		line.EntryID,
+		ofxEscaper.Replace(name),
+	)
+	return err
+}
+
+func (o *ofxWriter) WriteFooter(closingBalance int64) error {
+	_, err := fmt.Fprintf(o.w, `</BANKTRANLIST>
+<LEDGERBAL>
+<BALAMT>%s
+<DTASOF>%s
+</LEDGERBAL>
+</STMTRS>
+</STMTTRNRS>
+</BANKMSGSRSV1>
+</OFX>
+`,
+		formatAmount(closingBalance, o.currency, "."),
+		o.endTime.UTC().Format(ofxDateTime),
+	)
+	if err != nil {
+		return err
+	}
+
+	return o.w.Flush()
+}
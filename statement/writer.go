@@ -0,0 +1,59 @@
+// Package statement renders account statements in the formats accounting tools import
+package statement
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	db "github.com/haniifac/simplebank/db/sqlc"
+	"github.com/haniifac/simplebank/util"
+)
+
+const (
+	CSV   = "csv"
+	OFX   = "ofx"
+	MT940 = "mt940"
+)
+
+// Format describes how a statement export is served
+type Format struct {
+	ContentType string
+	Extension   string
+	NewWriter   func(w io.Writer) db.StatementWriter
+}
+
+var formats = map[string]Format{
+	CSV:   {ContentType: "text/csv; charset=utf-8", Extension: "csv", NewWriter: NewCSVWriter},
+	OFX:   {ContentType: "application/x-ofx", Extension: "ofx", NewWriter: NewOFXWriter},
+	MT940: {ContentType: "text/plain; charset=us-ascii", Extension: "sta", NewWriter: NewMT940Writer},
+}
+
+// Lookup returns the export format with the given name
+func Lookup(name string) (Format, bool) {
+	format, ok := formats[name]
+	return format, ok
+}
+
+// formatAmount formats an amount in minor units as a decimal number of the account currency
+func formatAmount(amount int64, currency string, separator string) string {
+	decimals := util.CurrencyDecimals(currency)
+
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	digits := strconv.FormatInt(amount, 10)
+	if decimals == 0 {
+		return sign + digits
+	}
+
+	if len(digits) <= decimals {
+		digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+	}
+	point := len(digits) - decimals
+	return fmt.Sprintf("%s%s%s%s", sign, digits[:point], separator, digits[point:])
+}
@@ -0,0 +1,94 @@
+package statement
+
+import (
+	"bytes"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	db "github.com/haniifac/simplebank/db/sqlc"
+	"github.com/haniifac/simplebank/util"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testAccount = db.Account{ID: 42, Owner: "alice", Currency: util.EUR, Number: "SB971234567890123456"}
+	testArg     = db.AccountStatementTxParams{
+		AccountID: 42,
+		StartTime: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+	}
+	testLines = []db.StatementLine{
+		{EntryID: 7, Amount: -1250, CreatedAt: time.Date(2024, 1, 5, 9, 30, 0, 0, time.UTC), JournalID: sql.NullInt64{Int64: 3, Valid: true}, Description: "transfer 3"},
+		{EntryID: 9, Amount: 5, CreatedAt: time.Date(2024, 1, 20, 16, 0, 0, 0, time.UTC), Description: "interest & <fees>"},
+	}
+)
+
+func writeStatement(t *testing.T, format string) string {
+	f, ok := Lookup(format)
+	require.True(t, ok)
+
+	var buf bytes.Buffer
+	w := f.NewWriter(&buf)
+
+	balance := int64(10000)
+	require.NoError(t, w.WriteHeader(testAccount, testArg, balance))
+	for _, line := range testLines {
+		balance += line.Amount
+		line.Balance = balance
+		require.NoError(t, w.WriteLine(line))
+	}
+	require.NoError(t, w.WriteFooter(balance))
+
+	return buf.String()
+}
+
+func TestFormatAmount(t *testing.T) {
+	require.Equal(t, "12.34", formatAmount(1234, util.USD, "."))
+	require.Equal(t, "-0.05", formatAmount(-5, util.USD, "."))
+	require.Equal(t, "0,00", formatAmount(0, util.EUR, ","))
+	require.Equal(t, "7", formatAmount(7, "XXX", "."))
+}
+
+func TestCSVWriter(t *testing.T) {
+	out := writeStatement(t, CSV)
+
+	require.Equal(t, strings.Join([]string{
+		"account_number,entry_id,date,description,amount,currency,balance",
+		"SB971234567890123456,7,2024-01-05T09:30:00Z,transfer 3,-12.50,EUR,87.50",
+		"SB971234567890123456,9,2024-01-20T16:00:00Z,interest & <fees>,0.05,EUR,87.55",
+		"",
+	}, "\n"), out)
+}
+
+func TestOFXWriter(t *testing.T) {
+	out := writeStatement(t, OFX)
+
+	require.True(t, strings.HasPrefix(out, "OFXHEADER:100\n"))
+	require.Contains(t, out, "<CURDEF>EUR\n")
+	require.Contains(t, out, "<ACCTID>SB971234567890123456\n")
+	require.Contains(t, out, "<DTSTART>20240101000000\n<DTEND>20240201000000\n")
+	require.Contains(t, out, "<TRNTYPE>DEBIT\n<DTPOSTED>20240105093000\n<TRNAMT>-12.50\n<FITID>7\n")
+	require.Contains(t, out, "<NAME>interest &amp; &lt;fees&gt;\n")
+	require.Contains(t, out, "<LEDGERBAL>\n<BALAMT>87.55\n")
+	require.True(t, strings.HasSuffix(out, "</OFX>\n"))
+}
+
+func TestMT940Writer(t *testing.T) {
+	out := writeStatement(t, MT940)
+
+	require.Equal(t, strings.Join([]string{
+		":20:STMT42240201",
+		":25:SB971234567890123456",
+		":28C:1/1",
+		":60F:C240101EUR100,00",
+		":61:2401050105D12,50NTRFNONREF//7",
+		":86:transfer 3",
+		":61:2401200120C0,05NTRFNONREF//9",
+		":86:interest    fees ",
+		":62F:C240131EUR87,55",
+		"-",
+		"",
+	}, "\r\n"), out)
+}
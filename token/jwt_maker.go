@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
 const (
@@ -32,6 +33,19 @@ func (maker *JWTMaker) CreateToken(username string, duration time.Duration) (str
 		return "", &Payload{}, err
 	}
 
+	return maker.sign(payload)
+}
+
+func (maker *JWTMaker) CreateSessionToken(username string, sessionID uuid.UUID, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewSessionPayload(username, sessionID, duration)
+	if err != nil {
+		return "", &Payload{}, err
+	}
+
+	return maker.sign(payload)
+}
+
+func (maker *JWTMaker) sign(payload *Payload) (string, *Payload, error) {
 	jwtToken := jwt.NewWithClaims(jwt.SigningMethodHS256, payload)
 	signedToken, err := jwtToken.SignedString([]byte(maker.secretKey))
 	if err != nil {
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/haniifac/simplebank/util"
 	"github.com/stretchr/testify/require"
 )
@@ -70,3 +71,16 @@ func TestJWTTokenInvalidAlg(t *testing.T) {
 	require.Contains(t, err.Error(), ErrInvalidToken.Error())
 	require.Nil(t, payload)
 }
+
+func TestJWTSessionToken(t *testing.T) {
+	maker, err := NewJWTMaker(util.RandomString(32))
+	require.NoError(t, err)
+
+	sessionID := uuid.New()
+	token, _, err := maker.CreateSessionToken(util.RandomOwner(), sessionID, time.Minute)
+	require.NoError(t, err)
+
+	payload, err := maker.VerifyToken(token)
+	require.NoError(t, err)
+	require.Equal(t, sessionID, payload.SessionID)
+}
@@ -1,8 +1,14 @@
 package token
 
-import "time"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type Maker interface {
 	CreateToken(username string, duration time.Duration) (string, *Payload, error)
+	// CreateSessionToken creates a token that is only valid as long as the login session is not blocked
+	CreateSessionToken(username string, sessionID uuid.UUID, duration time.Duration) (string, *Payload, error)
 	VerifyToken(token string) (*Payload, error)
 }
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/aead/chacha20poly1305"
+	"github.com/google/uuid"
 	"github.com/o1egl/paseto"
 )
 
@@ -37,6 +38,20 @@ func (p *PasetoMaker) CreateToken(username string, duration time.Duration) (stri
 		return "", &Payload{}, err
 	}
 
+	return p.encrypt(payload)
+}
+
+// CreateSessionToken implements Maker.
+func (p *PasetoMaker) CreateSessionToken(username string, sessionID uuid.UUID, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewSessionPayload(username, sessionID, duration)
+	if err != nil {
+		return "", &Payload{}, err
+	}
+
+	return p.encrypt(payload)
+}
+
+func (p *PasetoMaker) encrypt(payload *Payload) (string, *Payload, error) {
 	pasetoToken, err := p.paseto.Encrypt(p.symmetricKey, payload, nil)
 
 	return pasetoToken, payload, err
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/haniifac/simplebank/util"
 	"github.com/stretchr/testify/require"
 )
@@ -69,3 +70,16 @@ func TestVerifyToken(t *testing.T) {
 	require.Error(t, err)
 	require.Nil(t, payload)
 }
+
+func TestPasetoSessionToken(t *testing.T) {
+	maker, err := NewPasetoMaker(util.RandomString(32))
+	require.NoError(t, err)
+
+	sessionID := uuid.New()
+	token, _, err := maker.CreateSessionToken(util.RandomOwner(), sessionID, time.Minute)
+	require.NoError(t, err)
+
+	payload, err := maker.VerifyToken(token)
+	require.NoError(t, err)
+	require.Equal(t, sessionID, payload.SessionID)
+}
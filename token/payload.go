@@ -16,6 +16,8 @@ var (
 type Payload struct {
 	ID       uuid.UUID `json:"id"`
 	Username string    `json:"username"`
+	// SessionID is the login session an access token belongs to, empty for tokens not tied to one
+	SessionID uuid.UUID `json:"session_id"`
 	jwt.RegisteredClaims
 	// IssuedAt  time.Time `json:"issued_at"`
 	// ExpiredAt time.Time `json:"expired_at"`
@@ -45,6 +47,17 @@ func NewPayload(username string, duration time.Duration) (*Payload, error) {
 	return payload, nil
 }
 
+// NewSessionPayload creates the payload of a token that belongs to a login session
+func NewSessionPayload(username string, sessionID uuid.UUID, duration time.Duration) (*Payload, error) {
+	payload, err := NewPayload(username, duration)
+	if err != nil {
+		return nil, err
+	}
+
+	payload.SessionID = sessionID
+	return payload, nil
+}
+
 // Valid checks if the token payload is valid (e.g., not expired).
 func (payload *Payload) Valid() error {
 	if payload.ExpiresAt != nil && time.Now().After(payload.ExpiresAt.Time) {
@@ -0,0 +1,71 @@
+package util
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// AccountNumberPrefix takes the place of the IBAN country code in account numbers
+const AccountNumberPrefix = "SB"
+
+// accountNumberDigits is the length of the random part of account numbers
+const accountNumberDigits = 16
+
+// AccountNumberLength is the length of an account number: prefix, two check digits and the random part
+const AccountNumberLength = len(AccountNumberPrefix) + 2 + accountNumberDigits
+
+// NewAccountNumber generates a random IBAN style account number, such as SB971234567890123456,
+// whose check digits are computed like those of an IBAN (ISO 7064 mod 97-10)
+func NewAccountNumber() (string, error) {
+	n, err := rand.Int(rand.Reader, new(big.Int).Exp(big.NewInt(10), big.NewInt(accountNumberDigits), nil))
+	if err != nil {
+		return "", err
+	}
+
+	return accountNumber(n.Int64()), nil
+}
+
+// accountNumber prefixes the digits with their check digits
+func accountNumber(n int64) string {
+	digits := fmt.Sprintf("%0*d", accountNumberDigits, n)
+	check := 98 - mod97(digits+AccountNumberPrefix+"00")
+	return fmt.Sprintf("%s%02d%s", AccountNumberPrefix, check, digits)
+}
+
+// NormalizeAccountNumber removes the spaces account numbers are often grouped with and upper cases them
+func NormalizeAccountNumber(number string) string {
+	return strings.ToUpper(strings.ReplaceAll(number, " ", ""))
+}
+
+// IsValidAccountNumber reports whether number, once normalized, is an account number with correct check digits
+func IsValidAccountNumber(number string) bool {
+	number = NormalizeAccountNumber(number)
+	if len(number) != AccountNumberLength || !strings.HasPrefix(number, AccountNumberPrefix) {
+		return false
+	}
+
+	for _, c := range number[len(AccountNumberPrefix):] {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	// like an IBAN the prefix and check digits are moved to the end
+	return mod97(number[4:]+number[:4]) == 1
+}
+
+// mod97 is the remainder of s divided by 97, where letters stand for the numbers 10 (A) to 35 (Z)
+func mod97(s string) int {
+	remainder := 0
+	for _, c := range s {
+		switch {
+		case c >= '0' && c <= '9':
+			remainder = (remainder*10 + int(c-'0')) % 97
+		case c >= 'A' && c <= 'Z':
+			remainder = (remainder*100 + int(c-'A') + 10) % 97
+		}
+	}
+	return remainder
+}
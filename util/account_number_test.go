@@ -0,0 +1,49 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAccountNumber(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		number, err := NewAccountNumber()
+		require.NoError(t, err)
+		require.Len(t, number, AccountNumberLength)
+		require.True(t, IsValidAccountNumber(number), number)
+	}
+}
+
+func TestIsValidAccountNumber(t *testing.T) {
+	number, err := NewAccountNumber()
+	require.NoError(t, err)
+
+	// changing a single digit or swapping two neighbouring ones breaks the check digits
+	typo := []byte(number)
+	typo[10] = '0' + (typo[10]-'0'+1)%10
+	swapped := []byte(number)
+	swapped[10], swapped[11] = swapped[11], swapped[10]
+
+	testCases := []struct {
+		name   string
+		number string
+		valid  bool
+	}{
+		{"Valid", number, true},
+		{"Grouped", number[:4] + " " + number[4:8] + " " + number[8:], true},
+		{"LowerCase", "sb" + number[2:], true},
+		{"Typo", string(typo), false},
+		{"Swapped", string(swapped), swapped[10] == swapped[11]},
+		{"WrongPrefix", "XX" + number[2:], false},
+		{"TooShort", number[:len(number)-1], false},
+		{"Letters", number[:10] + "AB" + number[12:], false},
+		{"Empty", "", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.valid, IsValidAccountNumber(tc.number))
+		})
+	}
+}
@@ -8,12 +8,40 @@ import (
 
 // Stores all configuration of the application using viper
 type Config struct {
-	DBDriver             string        `mapstructure:"DB_DRIVER"`
-	DBSource             string        `mapstructure:"DB_SOURCE"`
-	ServerAddress        string        `mapstructure:"SERVER_ADDRESS"`
-	TokenSymmetricKey    string        `mapstructure:"TOKEN_SYMMETRIC_KEY"`
-	AccessTokenDuration  time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
-	RefreshTokenDuration time.Duration `mapstructure:"REFRESH_TOKEN_DURATION"`
+	DBDriver                   string        `mapstructure:"DB_DRIVER"`
+	DBSource                   string        `mapstructure:"DB_SOURCE"`
+	ServerAddress              string        `mapstructure:"SERVER_ADDRESS"`
+	TokenSymmetricKey          string        `mapstructure:"TOKEN_SYMMETRIC_KEY"`
+	AccessTokenDuration        time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
+	RefreshTokenDuration       time.Duration `mapstructure:"REFRESH_TOKEN_DURATION"`
+	IdempotencyKeyTTL          time.Duration `mapstructure:"IDEMPOTENCY_KEY_TTL"`
+	ScheduledTransferInterval  time.Duration `mapstructure:"SCHEDULED_TRANSFER_INTERVAL"`
+	StandingOrderInterval      time.Duration `mapstructure:"STANDING_ORDER_INTERVAL"`
+	StandingOrderRetryInterval time.Duration `mapstructure:"STANDING_ORDER_RETRY_INTERVAL"`
+	ExchangeQuoteDuration      time.Duration `mapstructure:"EXCHANGE_QUOTE_DURATION"`
+	TxIsolationLevel           string        `mapstructure:"TX_ISOLATION_LEVEL"`
+	TxMaxAttempts              int           `mapstructure:"TX_MAX_ATTEMPTS"`
+	TxRetryBaseDelay           time.Duration `mapstructure:"TX_RETRY_BASE_DELAY"`
+	TxRetryMaxDelay            time.Duration `mapstructure:"TX_RETRY_MAX_DELAY"`
+	HoldDuration               time.Duration `mapstructure:"HOLD_DURATION"`
+	HoldSweepInterval          time.Duration `mapstructure:"HOLD_SWEEP_INTERVAL"`
+	InterestInterval           time.Duration `mapstructure:"INTEREST_INTERVAL"`
+	InterestRate               string        `mapstructure:"INTEREST_RATE"`
+	InterestDayCount           string        `mapstructure:"INTEREST_DAY_COUNT"`
+	FeeInterval                time.Duration `mapstructure:"FEE_INTERVAL"`
+	ReconcileInterval          time.Duration `mapstructure:"RECONCILE_INTERVAL"`
+	ReconcileBatchSize         int32         `mapstructure:"RECONCILE_BATCH_SIZE"`
+	BalanceSnapshotInterval    time.Duration `mapstructure:"BALANCE_SNAPSHOT_INTERVAL"`
+	DailyTransferLimit         int64         `mapstructure:"DAILY_TRANSFER_LIMIT"`
+	MonthlyTransferLimit       int64         `mapstructure:"MONTHLY_TRANSFER_LIMIT"`
+	PasswordResetTokenDuration time.Duration `mapstructure:"PASSWORD_RESET_TOKEN_DURATION"`
+	EmailVerificationDuration  time.Duration `mapstructure:"EMAIL_VERIFICATION_DURATION"`
+	RequireVerifiedEmail       bool          `mapstructure:"REQUIRE_VERIFIED_EMAIL"`
+	MailFrom                   string        `mapstructure:"MAIL_FROM"`
+	MailDir                    string        `mapstructure:"MAIL_DIR"`
+	SMTPAddress                string        `mapstructure:"SMTP_ADDRESS"`
+	SMTPUsername               string        `mapstructure:"SMTP_USERNAME"`
+	SMTPPassword               string        `mapstructure:"SMTP_PASSWORD"`
 }
 
 func LoadConfig(path string) (config Config, err error) {
@@ -13,3 +13,12 @@ func IsSupportedCurrency(currency string) bool {
 	}
 	return false
 }
+
+// CurrencyDecimals returns the number of minor unit digits amounts of the currency are stored with
+func CurrencyDecimals(currency string) int {
+	switch currency {
+	case USD, EUR, CAD:
+		return 2
+	}
+	return 0
+}
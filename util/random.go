@@ -39,3 +39,7 @@ func RandomCurrency() string {
 func RandomEmail() string {
 	return RandomString(6) + "@testmail.com"
 }
+
+func RandomAccountNumber() string {
+	return accountNumber(rand.Int63n(1e16))
+}
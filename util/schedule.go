@@ -0,0 +1,42 @@
+package util
+
+import "time"
+
+// Standing order frequencies
+const (
+	Weekly     = "weekly"
+	Monthly    = "monthly"
+	EndOfMonth = "end_of_month"
+)
+
+func IsSupportedFrequency(frequency string) bool {
+	switch frequency {
+	case Weekly, Monthly, EndOfMonth:
+		return true
+	}
+	return false
+}
+
+// NextOccurrence returns the occurrence that follows from for the given frequency.
+// Monthly occurrences fall on dayOfMonth, or on the last day of months that are too short.
+func NextOccurrence(frequency string, dayOfMonth int, from time.Time) time.Time {
+	switch frequency {
+	case Weekly:
+		return from.AddDate(0, 0, 7)
+	case Monthly:
+		return monthDay(from, 1, dayOfMonth)
+	case EndOfMonth:
+		return monthDay(from, 1, 31)
+	}
+	return from
+}
+
+// monthDay returns day of the month that is months after from, clamped to the length of that month
+func monthDay(from time.Time, months int, day int) time.Time {
+	first := time.Date(from.Year(), from.Month()+time.Month(months), 1, from.Hour(), from.Minute(), from.Second(), from.Nanosecond(), from.Location())
+	lastDay := first.AddDate(0, 1, -1).Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return first.AddDate(0, 0, day-1)
+}
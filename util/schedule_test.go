@@ -0,0 +1,58 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextOccurrence(t *testing.T) {
+	testCases := []struct {
+		name       string
+		frequency  string
+		dayOfMonth int
+		from       time.Time
+		expected   time.Time
+	}{
+		{
+			name:      "Weekly",
+			frequency: Weekly,
+			from:      time.Date(2026, 3, 28, 9, 0, 0, 0, time.UTC),
+			expected:  time.Date(2026, 4, 4, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "Monthly",
+			frequency:  Monthly,
+			dayOfMonth: 15,
+			from:       time.Date(2026, 12, 15, 9, 0, 0, 0, time.UTC),
+			expected:   time.Date(2027, 1, 15, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "MonthlyShortMonth",
+			frequency:  Monthly,
+			dayOfMonth: 31,
+			from:       time.Date(2026, 1, 31, 9, 0, 0, 0, time.UTC),
+			expected:   time.Date(2026, 2, 28, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "MonthlyAfterShortMonth",
+			frequency:  Monthly,
+			dayOfMonth: 31,
+			from:       time.Date(2026, 2, 28, 9, 0, 0, 0, time.UTC),
+			expected:   time.Date(2026, 3, 31, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "EndOfMonth",
+			frequency: EndOfMonth,
+			from:      time.Date(2028, 1, 31, 9, 0, 0, 0, time.UTC),
+			expected:  time.Date(2028, 2, 29, 9, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, NextOccurrence(tc.frequency, tc.dayOfMonth, tc.from))
+		})
+	}
+}
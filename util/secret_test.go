@@ -0,0 +1,22 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecret(t *testing.T) {
+	secret, err := NewSecret()
+	require.NoError(t, err)
+	require.Len(t, secret, 43)
+
+	other, err := NewSecret()
+	require.NoError(t, err)
+	require.NotEqual(t, secret, other)
+
+	hash := HashSecret(secret)
+	require.Len(t, hash, 64)
+	require.Equal(t, hash, HashSecret(secret))
+	require.NotEqual(t, hash, HashSecret(other))
+}
@@ -0,0 +1,76 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+
+	db "github.com/haniifac/simplebank/db/sqlc"
+)
+
+// snapshotBatchSize is how many accounts are snapshotted at once
+const snapshotBatchSize = 100
+
+// snapshotLag is how long after midnight the snapshot of the day is taken. Entries carry the start time
+// of their transaction, so transfers that started before midnight must have committed by then.
+const snapshotLag = time.Hour
+
+// BalanceSnapshotProcessor periodically records the balance of every account at the start of the UTC day,
+// which point in time balance queries start from
+type BalanceSnapshotProcessor struct {
+	store    db.Store
+	interval time.Duration
+}
+
+func NewBalanceSnapshotProcessor(store db.Store, interval time.Duration) *BalanceSnapshotProcessor {
+	return &BalanceSnapshotProcessor{
+		store:    store,
+		interval: interval,
+	}
+}
+
+// Start takes snapshots until ctx is cancelled
+func (processor *BalanceSnapshotProcessor) Start(ctx context.Context) {
+	poll(ctx, processor.interval, processor.ProcessDue)
+}
+
+// ProcessDue takes the snapshots of the current day
+func (processor *BalanceSnapshotProcessor) ProcessDue(ctx context.Context) {
+	processor.SnapshotDue(ctx, time.Now())
+}
+
+// SnapshotDue snapshots the balances at the last midnight that is at least snapshotLag before now
+// for every account opened before it. Accounts that already have the snapshot are skipped.
+func (processor *BalanceSnapshotProcessor) SnapshotDue(ctx context.Context, now time.Time) {
+	takenAt := utcDay(now.Add(-snapshotLag))
+
+	var afterID int64
+	for ctx.Err() == nil {
+		ids, err := processor.store.ListSnapshotAccountIds(ctx, db.ListSnapshotAccountIdsParams{
+			CreatedBefore: takenAt,
+			AfterID:       afterID,
+			Limit:         snapshotBatchSize,
+		})
+		if err != nil {
+			log.Printf("cannot list accounts to snapshot: %v", err)
+			return
+		}
+
+		for _, id := range ids {
+			_, err := processor.store.CreateBalanceSnapshot(ctx, db.CreateBalanceSnapshotParams{
+				TakenAt:   takenAt,
+				AccountID: id,
+			})
+			if err != nil && !errors.Is(err, sql.ErrNoRows) {
+				log.Printf("cannot snapshot balance of account %d: %v", id, err)
+			}
+		}
+
+		if len(ids) < snapshotBatchSize {
+			return
+		}
+		afterID = ids[len(ids)-1]
+	}
+}
@@ -0,0 +1,57 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	mockdb "github.com/haniifac/simplebank/db/mock"
+	db "github.com/haniifac/simplebank/db/sqlc"
+	"go.uber.org/mock/gomock"
+)
+
+func TestBalanceSnapshotDue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+
+	// within the lag after midnight the snapshot is still taken at the previous midnight
+	now := time.Date(2024, time.March, 4, 0, 30, 0, 0, time.UTC)
+	takenAt := time.Date(2024, time.March, 3, 0, 0, 0, 0, time.UTC)
+
+	ids := make([]int64, snapshotBatchSize)
+	for i := range ids {
+		ids[i] = int64(i + 1)
+	}
+
+	store.EXPECT().
+		ListSnapshotAccountIds(gomock.Any(), gomock.Eq(db.ListSnapshotAccountIdsParams{
+			CreatedBefore: takenAt,
+			Limit:         snapshotBatchSize,
+		})).
+		Times(1).
+		Return(ids, nil)
+	store.EXPECT().
+		ListSnapshotAccountIds(gomock.Any(), gomock.Eq(db.ListSnapshotAccountIdsParams{
+			CreatedBefore: takenAt,
+			AfterID:       snapshotBatchSize,
+			Limit:         snapshotBatchSize,
+		})).
+		Times(1).
+		Return(nil, nil)
+
+	// accounts that were already snapshotted are skipped
+	store.EXPECT().
+		CreateBalanceSnapshot(gomock.Any(), gomock.Eq(db.CreateBalanceSnapshotParams{TakenAt: takenAt, AccountID: 1})).
+		Times(1).
+		Return(db.BalanceSnapshot{}, sql.ErrNoRows)
+	store.EXPECT().
+		CreateBalanceSnapshot(gomock.Any(), gomock.Any()).
+		Times(snapshotBatchSize-1).
+		Return(db.BalanceSnapshot{}, nil)
+
+	processor := NewBalanceSnapshotProcessor(store, 0)
+	processor.SnapshotDue(context.Background(), now)
+}
@@ -0,0 +1,83 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	db "github.com/haniifac/simplebank/db/sqlc"
+)
+
+// feeBatchSize is how many accounts with a fee schedule are loaded at once
+const feeBatchSize = 100
+
+// FeeProcessor periodically charges the monthly fees of the accounts with a fee schedule
+type FeeProcessor struct {
+	store    db.Store
+	interval time.Duration
+}
+
+func NewFeeProcessor(store db.Store, interval time.Duration) *FeeProcessor {
+	return &FeeProcessor{
+		store:    store,
+		interval: interval,
+	}
+}
+
+// Start charges fees until ctx is cancelled
+func (processor *FeeProcessor) Start(ctx context.Context) {
+	poll(ctx, processor.interval, processor.ProcessDue)
+}
+
+// ProcessDue charges the fees of every month that has ended
+func (processor *FeeProcessor) ProcessDue(ctx context.Context) {
+	processor.ChargeDue(ctx, time.Now())
+}
+
+// ChargeDue charges the periodic fees of every month before the one of now that an account has not settled yet.
+// Months missed while the processor was not running or that the account could not pay for are caught up.
+// Fees already charged are skipped by ChargePeriodicFeesTx.
+func (processor *FeeProcessor) ChargeDue(ctx context.Context, now time.Time) {
+	today := utcDay(now)
+	periodEnd := today.AddDate(0, 0, 1-today.Day())
+
+	var afterID int64
+	for ctx.Err() == nil {
+		accounts, err := processor.store.ListFeeAccounts(ctx, db.ListFeeAccountsParams{
+			PeriodEnd: periodEnd,
+			AfterID:   afterID,
+			Limit:     feeBatchSize,
+		})
+		if err != nil {
+			log.Printf("cannot list accounts with fees: %v", err)
+			return
+		}
+
+		for _, account := range accounts {
+			processor.chargeAccount(ctx, account, periodEnd)
+		}
+
+		if len(accounts) < feeBatchSize {
+			return
+		}
+		afterID = accounts[len(accounts)-1].ID
+	}
+}
+
+// chargeAccount charges every month after the last one the account settled, up to the one ending at until.
+// A month that fails does not stop the later ones, it is tried again on the next run.
+func (processor *FeeProcessor) chargeAccount(ctx context.Context, account db.Account, until time.Time) {
+	for periodEnd := utcDay(account.FeesChargedUntil.Time).AddDate(0, 1, 0); !periodEnd.After(until) && ctx.Err() == nil; periodEnd = periodEnd.AddDate(0, 1, 0) {
+		charges, err := processor.store.ChargePeriodicFeesTx(ctx, db.ChargePeriodicFeesTxParams{
+			AccountID: account.ID,
+			PeriodEnd: periodEnd,
+		})
+
+		for _, charge := range charges {
+			log.Printf("charged %s fee of %d to account %d", charge.Kind, charge.Amount, account.ID)
+		}
+		if err != nil {
+			log.Printf("cannot charge fees of account %d for %s: %v", account.ID, periodEnd.AddDate(0, -1, 0).Format("2006-01"), err)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	mockdb "github.com/haniifac/simplebank/db/mock"
+	db "github.com/haniifac/simplebank/db/sqlc"
+	"go.uber.org/mock/gomock"
+)
+
+func TestFeeChargeDue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+
+	now := time.Date(2024, time.March, 4, 15, 0, 0, 0, time.UTC)
+	periodEnd := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	store.EXPECT().
+		ListFeeAccounts(gomock.Any(), gomock.Eq(db.ListFeeAccountsParams{
+			PeriodEnd: periodEnd,
+			Limit:     feeBatchSize,
+		})).
+		Times(1).
+		Return([]db.Account{
+			{ID: 1, FeesChargedUntil: sql.NullTime{Time: periodEnd.AddDate(0, -1, 0), Valid: true}},
+			{ID: 2, FeesChargedUntil: sql.NullTime{Time: periodEnd.AddDate(0, -3, 0), Valid: true}},
+		}, nil)
+
+	// a failing account does not stop the others from being charged
+	store.EXPECT().
+		ChargePeriodicFeesTx(gomock.Any(), gomock.Eq(db.ChargePeriodicFeesTxParams{AccountID: 1, PeriodEnd: periodEnd})).
+		Times(1).
+		Return(nil, db.ErrInsufficientFunds)
+
+	// the months an account has not settled are caught up, a failing month does not stop the later ones
+	store.EXPECT().
+		ChargePeriodicFeesTx(gomock.Any(), gomock.Eq(db.ChargePeriodicFeesTxParams{AccountID: 2, PeriodEnd: periodEnd.AddDate(0, -2, 0)})).
+		Times(1).
+		Return(nil, db.ErrInsufficientFunds)
+	store.EXPECT().
+		ChargePeriodicFeesTx(gomock.Any(), gomock.Eq(db.ChargePeriodicFeesTxParams{AccountID: 2, PeriodEnd: periodEnd.AddDate(0, -1, 0)})).
+		Times(1).
+		Return([]db.FeeCharge{}, nil)
+	store.EXPECT().
+		ChargePeriodicFeesTx(gomock.Any(), gomock.Eq(db.ChargePeriodicFeesTxParams{AccountID: 2, PeriodEnd: periodEnd})).
+		Times(1).
+		Return([]db.FeeCharge{{
+			AccountID: 2,
+			Kind:      db.FeeMonthly,
+			Amount:    500,
+			PeriodEnd: sql.NullTime{Time: periodEnd, Valid: true},
+		}}, nil)
+
+	processor := NewFeeProcessor(store, 0)
+	processor.ChargeDue(context.Background(), now)
+}
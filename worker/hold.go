@@ -0,0 +1,40 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	db "github.com/haniifac/simplebank/db/sqlc"
+)
+
+// HoldSweeper periodically expires holds that were neither captured nor released in time
+type HoldSweeper struct {
+	store    db.Store
+	interval time.Duration
+}
+
+func NewHoldSweeper(store db.Store, interval time.Duration) *HoldSweeper {
+	return &HoldSweeper{
+		store:    store,
+		interval: interval,
+	}
+}
+
+// Start sweeps expired holds until ctx is cancelled
+func (sweeper *HoldSweeper) Start(ctx context.Context) {
+	poll(ctx, sweeper.interval, sweeper.ExpireDue)
+}
+
+// ExpireDue marks every active hold past its expiry as expired
+func (sweeper *HoldSweeper) ExpireDue(ctx context.Context) {
+	holds, err := sweeper.store.ExpireHolds(ctx)
+	if err != nil {
+		log.Printf("cannot expire holds: %v", err)
+		return
+	}
+
+	for _, hold := range holds {
+		log.Printf("hold %d on account %d expired", hold.ID, hold.AccountID)
+	}
+}
@@ -0,0 +1,24 @@
+package worker
+
+import (
+	"context"
+	"testing"
+
+	mockdb "github.com/haniifac/simplebank/db/mock"
+	db "github.com/haniifac/simplebank/db/sqlc"
+	"go.uber.org/mock/gomock"
+)
+
+func TestExpireDue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		ExpireHolds(gomock.Any()).
+		Times(1).
+		Return([]db.Hold{{ID: 1, AccountID: 2, Status: db.HoldExpired}}, nil)
+
+	sweeper := NewHoldSweeper(store, 0)
+	sweeper.ExpireDue(context.Background())
+}
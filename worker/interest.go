@@ -0,0 +1,135 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"math/big"
+	"time"
+
+	db "github.com/haniifac/simplebank/db/sqlc"
+	"github.com/haniifac/simplebank/interest"
+)
+
+// interestBatchSize is how many savings accounts are loaded at once while accruing interest
+const interestBatchSize = 100
+
+// InterestProcessor accrues daily interest on savings accounts and posts it once a month
+type InterestProcessor struct {
+	store    db.Store
+	interval time.Duration
+	rate     *big.Rat
+	dayCount interest.DayCount
+}
+
+func NewInterestProcessor(store db.Store, interval time.Duration, rate *big.Rat, dayCount interest.DayCount) *InterestProcessor {
+	return &InterestProcessor{
+		store:    store,
+		interval: interval,
+		rate:     rate,
+		dayCount: dayCount,
+	}
+}
+
+// Start accrues and posts interest until ctx is cancelled
+func (processor *InterestProcessor) Start(ctx context.Context) {
+	poll(ctx, processor.interval, processor.ProcessDue)
+}
+
+// ProcessDue accrues interest up to the end of yesterday and then posts the months that have ended
+func (processor *InterestProcessor) ProcessDue(ctx context.Context) {
+	now := time.Now()
+	processor.AccrueDue(ctx, now)
+	processor.PostDue(ctx, now)
+}
+
+// AccrueDue accrues interest for every day up to the one before now that a savings account has not accrued yet.
+// Days missed while the processor was not running are caught up.
+func (processor *InterestProcessor) AccrueDue(ctx context.Context, now time.Time) {
+	yesterday := utcDay(now).AddDate(0, 0, -1)
+
+	var afterID int64
+	for ctx.Err() == nil {
+		accounts, err := processor.store.ListInterestAccounts(ctx, db.ListInterestAccountsParams{
+			AfterID: afterID,
+			Limit:   interestBatchSize,
+		})
+		if err != nil {
+			log.Printf("cannot list savings accounts: %v", err)
+			return
+		}
+
+		for _, account := range accounts {
+			processor.accrueAccount(ctx, account, yesterday)
+		}
+
+		if len(accounts) < interestBatchSize {
+			return
+		}
+		afterID = accounts[len(accounts)-1].ID
+	}
+}
+
+func (processor *InterestProcessor) accrueAccount(ctx context.Context, account db.Account, until time.Time) {
+	day := utcDay(account.CreatedAt)
+
+	last, err := processor.store.GetLastInterestAccrual(ctx, account.ID)
+	switch {
+	case err == nil:
+		day = utcDay(last.AccrualDate).AddDate(0, 0, 1)
+	case !errors.Is(err, sql.ErrNoRows):
+		log.Printf("cannot get last interest accrual of account %d: %v", account.ID, err)
+		return
+	}
+
+	for ; !day.After(until) && ctx.Err() == nil; day = day.AddDate(0, 0, 1) {
+		_, err := processor.store.AccrueInterestTx(ctx, db.AccrueInterestTxParams{
+			AccountID: account.ID,
+			Date:      day,
+			Rate:      processor.rate,
+			DayCount:  processor.dayCount,
+		})
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			log.Printf("cannot accrue interest of account %d for %s: %v", account.ID, day.Format(time.DateOnly), err)
+			return
+		}
+	}
+}
+
+// PostDue posts the interest accrued before the month of now
+func (processor *InterestProcessor) PostDue(ctx context.Context, now time.Time) {
+	today := utcDay(now)
+	periodEnd := today.AddDate(0, 0, 1-today.Day())
+
+	ids, err := processor.store.ListUnpostedInterestAccountIds(ctx, periodEnd)
+	if err != nil {
+		log.Printf("cannot list accounts with accrued interest: %v", err)
+		return
+	}
+
+	for _, id := range ids {
+		if ctx.Err() != nil {
+			return
+		}
+
+		result, err := processor.store.PostInterestTx(ctx, db.PostInterestTxParams{
+			AccountID: id,
+			PeriodEnd: periodEnd,
+		})
+		if err != nil {
+			if !errors.Is(err, db.ErrNoInterestAccrued) {
+				log.Printf("cannot post interest of account %d: %v", id, err)
+			}
+			continue
+		}
+
+		log.Printf("posted %d interest to account %d, carrying %s", result.Posting.Amount, id, result.Posting.Carry)
+	}
+}
+
+// utcDay truncates t to the start of its day in UTC
+func utcDay(t time.Time) time.Time {
+	year, month, day := t.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
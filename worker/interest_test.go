@@ -0,0 +1,102 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"math/big"
+	"testing"
+	"time"
+
+	mockdb "github.com/haniifac/simplebank/db/mock"
+	db "github.com/haniifac/simplebank/db/sqlc"
+	"github.com/haniifac/simplebank/interest"
+	"go.uber.org/mock/gomock"
+)
+
+func TestInterestAccrueDue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+
+	now := time.Date(2024, time.March, 4, 15, 0, 0, 0, time.UTC)
+	caughtUp := db.Account{ID: 1, Kind: db.AccountSavings, CreatedAt: now.AddDate(0, -1, 0)}
+	behind := db.Account{ID: 2, Kind: db.AccountSavings, CreatedAt: now.AddDate(0, -1, 0)}
+	opened := db.Account{ID: 3, Kind: db.AccountSavings, CreatedAt: now.Add(-24 * time.Hour)}
+
+	store.EXPECT().
+		ListInterestAccounts(gomock.Any(), gomock.Eq(db.ListInterestAccountsParams{Limit: interestBatchSize})).
+		Times(1).
+		Return([]db.Account{caughtUp, behind, opened}, nil)
+
+	store.EXPECT().
+		GetLastInterestAccrual(gomock.Any(), gomock.Eq(caughtUp.ID)).
+		Times(1).
+		Return(db.InterestAccrual{AccrualDate: time.Date(2024, time.March, 3, 0, 0, 0, 0, time.UTC)}, nil)
+
+	// the days missed since the last accrual are caught up
+	store.EXPECT().
+		GetLastInterestAccrual(gomock.Any(), gomock.Eq(behind.ID)).
+		Times(1).
+		Return(db.InterestAccrual{AccrualDate: time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)}, nil)
+	for _, day := range []int{1, 2, 3} {
+		store.EXPECT().
+			AccrueInterestTx(gomock.Any(), gomock.Eq(db.AccrueInterestTxParams{
+				AccountID: behind.ID,
+				Date:      time.Date(2024, time.March, day, 0, 0, 0, 0, time.UTC),
+				Rate:      big.NewRat(2, 100),
+				DayCount:  interest.ActualActual,
+			})).
+			Times(1)
+	}
+
+	// a new account accrues from the day it was opened
+	store.EXPECT().
+		GetLastInterestAccrual(gomock.Any(), gomock.Eq(opened.ID)).
+		Times(1).
+		Return(db.InterestAccrual{}, sql.ErrNoRows)
+	store.EXPECT().
+		AccrueInterestTx(gomock.Any(), gomock.Eq(db.AccrueInterestTxParams{
+			AccountID: opened.ID,
+			Date:      time.Date(2024, time.March, 3, 0, 0, 0, 0, time.UTC),
+			Rate:      big.NewRat(2, 100),
+			DayCount:  interest.ActualActual,
+		})).
+		Times(1).
+		Return(db.InterestAccrual{}, sql.ErrNoRows)
+
+	processor := NewInterestProcessor(store, 0, big.NewRat(2, 100), interest.ActualActual)
+	processor.AccrueDue(context.Background(), now)
+}
+
+func TestInterestPostDue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+
+	now := time.Date(2024, time.March, 4, 15, 0, 0, 0, time.UTC)
+	periodEnd := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	store.EXPECT().
+		ListUnpostedInterestAccountIds(gomock.Any(), gomock.Eq(periodEnd)).
+		Times(1).
+		Return([]int64{1, 2, 3}, nil)
+
+	// a failing account does not stop the others from being posted
+	store.EXPECT().
+		PostInterestTx(gomock.Any(), gomock.Eq(db.PostInterestTxParams{AccountID: 1, PeriodEnd: periodEnd})).
+		Times(1).
+		Return(db.PostInterestTxResult{}, db.ErrNoBankAccount)
+	store.EXPECT().
+		PostInterestTx(gomock.Any(), gomock.Eq(db.PostInterestTxParams{AccountID: 2, PeriodEnd: periodEnd})).
+		Times(1).
+		Return(db.PostInterestTxResult{}, db.ErrNoInterestAccrued)
+	store.EXPECT().
+		PostInterestTx(gomock.Any(), gomock.Eq(db.PostInterestTxParams{AccountID: 3, PeriodEnd: periodEnd})).
+		Times(1).
+		Return(db.PostInterestTxResult{Posting: db.InterestPosting{Amount: 12, Carry: "0.5"}}, nil)
+
+	processor := NewInterestProcessor(store, 0, big.NewRat(2, 100), interest.ActualActual)
+	processor.PostDue(context.Background(), now)
+}
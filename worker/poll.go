@@ -0,0 +1,21 @@
+package worker
+
+import (
+	"context"
+	"time"
+)
+
+// poll calls fn every interval until ctx is cancelled
+func poll(ctx context.Context, interval time.Duration, fn func(ctx context.Context)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			fn(ctx)
+		}
+	}
+}
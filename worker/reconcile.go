@@ -0,0 +1,49 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	db "github.com/haniifac/simplebank/db/sqlc"
+	"github.com/haniifac/simplebank/reconcile"
+)
+
+// ReconcileProcessor periodically checks the ledger and stores the report of every run
+type ReconcileProcessor struct {
+	store      db.Store
+	reconciler *reconcile.Reconciler
+	interval   time.Duration
+}
+
+func NewReconcileProcessor(store db.Store, reconciler *reconcile.Reconciler, interval time.Duration) *ReconcileProcessor {
+	return &ReconcileProcessor{
+		store:      store,
+		reconciler: reconciler,
+		interval:   interval,
+	}
+}
+
+// Start reconciles the ledger until ctx is cancelled
+func (processor *ReconcileProcessor) Start(ctx context.Context) {
+	poll(ctx, processor.interval, processor.ProcessDue)
+}
+
+// ProcessDue runs a reconciliation and stores its report
+func (processor *ReconcileProcessor) ProcessDue(ctx context.Context) {
+	report, err := processor.reconciler.Run(ctx)
+	if err != nil {
+		log.Printf("cannot reconcile ledger: %v", err)
+		return
+	}
+
+	if _, err := reconcile.Save(ctx, processor.store, report); err != nil {
+		log.Printf("cannot save reconciliation report: %v", err)
+		return
+	}
+
+	if !report.OK {
+		log.Printf("ledger does not reconcile: %d balance mismatches, %d transfer mismatches, %d orphaned entries, %d unbalanced journals",
+			len(report.BalanceMismatches), len(report.TransferMismatches), len(report.OrphanedEntries), len(report.UnbalancedJournals))
+	}
+}
@@ -0,0 +1,43 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	mockdb "github.com/haniifac/simplebank/db/mock"
+	db "github.com/haniifac/simplebank/db/sqlc"
+	"github.com/haniifac/simplebank/reconcile"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestReconcileProcessDue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		ListAccountEntryTotals(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return([]db.ListAccountEntryTotalsRow{{ID: 1, Balance: 10, EntriesTotal: 0}}, nil)
+	store.EXPECT().ListTransferEntryTotals(gomock.Any(), gomock.Any()).Times(1).Return(nil, nil)
+	store.EXPECT().ListJournalCurrencyTotals(gomock.Any(), gomock.Any()).Times(1).Return(nil, nil)
+	store.EXPECT().ListOrphanedEntries(gomock.Any(), gomock.Any()).Times(1).Return(nil, nil)
+
+	store.EXPECT().
+		CreateReconciliationReport(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg db.CreateReconciliationReportParams) (db.ReconciliationReport, error) {
+			require.False(t, arg.Ok)
+
+			var report reconcile.Report
+			require.NoError(t, json.Unmarshal(arg.Report, &report))
+			require.Len(t, report.BalanceMismatches, 1)
+			require.EqualValues(t, 1, report.BalanceMismatches[0].AccountID)
+			return db.ReconciliationReport{ID: 1, Ok: arg.Ok, Report: arg.Report}, nil
+		})
+
+	processor := NewReconcileProcessor(store, reconcile.NewReconciler(store, 10), 0)
+	processor.ProcessDue(context.Background())
+}
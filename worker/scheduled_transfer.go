@@ -0,0 +1,47 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+
+	db "github.com/haniifac/simplebank/db/sqlc"
+)
+
+// ScheduledTransferProcessor periodically executes scheduled transfers that have become due
+type ScheduledTransferProcessor struct {
+	store    db.Store
+	interval time.Duration
+}
+
+func NewScheduledTransferProcessor(store db.Store, interval time.Duration) *ScheduledTransferProcessor {
+	return &ScheduledTransferProcessor{
+		store:    store,
+		interval: interval,
+	}
+}
+
+// Start polls for due scheduled transfers until ctx is cancelled
+func (processor *ScheduledTransferProcessor) Start(ctx context.Context) {
+	poll(ctx, processor.interval, processor.ProcessDue)
+}
+
+// ProcessDue executes scheduled transfers one by one until none is due.
+// Transfers that cannot be made are marked as failed by ExecuteScheduledTransferTx itself. Any other error, such as
+// a lost connection or exhausted retries, rolls the execution back and leaves the row pending, so the run stops and
+// the next poll tries again.
+func (processor *ScheduledTransferProcessor) ProcessDue(ctx context.Context) {
+	for ctx.Err() == nil {
+		result, err := processor.store.ExecuteScheduledTransferTx(ctx)
+		if err != nil {
+			if !errors.Is(err, sql.ErrNoRows) {
+				log.Printf("cannot execute scheduled transfer %d: %v", result.ScheduledTransfer.ID, err)
+			}
+			return
+		}
+
+		log.Printf("scheduled transfer %d %s", result.ScheduledTransfer.ID, result.ScheduledTransfer.Status)
+	}
+}
@@ -0,0 +1,38 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	mockdb "github.com/haniifac/simplebank/db/mock"
+	db "github.com/haniifac/simplebank/db/sqlc"
+	"go.uber.org/mock/gomock"
+)
+
+func TestProcessDue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+
+	executed := db.ExecuteScheduledTransferTxResult{
+		ScheduledTransfer: db.ScheduledTransfer{ID: 1, Status: db.ScheduledTransferExecuted},
+	}
+	broken := db.ExecuteScheduledTransferTxResult{
+		ScheduledTransfer: db.ScheduledTransfer{ID: 2, Status: db.ScheduledTransferPending},
+	}
+
+	gomock.InOrder(
+		store.EXPECT().ExecuteScheduledTransferTx(gomock.Any()).Times(1).Return(executed, nil),
+		// a transient error leaves the row pending for the next poll instead of marking it as failed
+		store.EXPECT().ExecuteScheduledTransferTx(gomock.Any()).Times(1).Return(broken, sql.ErrConnDone),
+	)
+	store.EXPECT().UpdateScheduledTransferResult(gomock.Any(), gomock.Any()).Times(0)
+
+	processor := NewScheduledTransferProcessor(store, 0)
+	processor.ProcessDue(context.Background())
+
+	store.EXPECT().ExecuteScheduledTransferTx(gomock.Any()).Times(1).Return(db.ExecuteScheduledTransferTxResult{}, sql.ErrNoRows)
+	processor.ProcessDue(context.Background())
+}
@@ -0,0 +1,66 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+
+	db "github.com/haniifac/simplebank/db/sqlc"
+)
+
+// StandingOrderProcessor periodically runs the standing orders that have become due
+type StandingOrderProcessor struct {
+	store         db.Store
+	interval      time.Duration
+	retryInterval time.Duration
+}
+
+func NewStandingOrderProcessor(store db.Store, interval time.Duration, retryInterval time.Duration) *StandingOrderProcessor {
+	return &StandingOrderProcessor{
+		store:         store,
+		interval:      interval,
+		retryInterval: retryInterval,
+	}
+}
+
+// Start polls for due standing orders until ctx is cancelled
+func (processor *StandingOrderProcessor) Start(ctx context.Context) {
+	poll(ctx, processor.interval, processor.ProcessDue)
+}
+
+// ProcessDue runs standing orders one by one until none is due
+func (processor *StandingOrderProcessor) ProcessDue(ctx context.Context) {
+	arg := db.ExecuteStandingOrderTxParams{
+		RetryInterval: processor.retryInterval,
+	}
+
+	for ctx.Err() == nil {
+		result, err := processor.store.ExecuteStandingOrderTx(ctx, arg)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return
+			}
+
+			order := result.StandingOrder
+			log.Printf("cannot execute standing order %d: %v", order.ID, err)
+			if order.ID == 0 {
+				return
+			}
+
+			// push the order back to the next poll so it does not block the others
+			err = processor.store.PostponeStandingOrder(ctx, db.PostponeStandingOrderParams{
+				ID:      order.ID,
+				RetryAt: sql.NullTime{Time: time.Now().Add(processor.interval), Valid: true},
+			})
+			if err != nil {
+				log.Printf("cannot postpone standing order %d: %v", order.ID, err)
+				return
+			}
+			continue
+		}
+
+		log.Printf("standing order %d execution %s", result.StandingOrder.ID, result.Execution.Status)
+	}
+}
@@ -0,0 +1,42 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	mockdb "github.com/haniifac/simplebank/db/mock"
+	db "github.com/haniifac/simplebank/db/sqlc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestProcessDueStandingOrders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+
+	arg := db.ExecuteStandingOrderTxParams{RetryInterval: time.Hour}
+	broken := db.ExecuteStandingOrderTxResult{
+		StandingOrder: db.StandingOrder{ID: 1, Status: db.StandingOrderActive, NextRunAt: time.Now()},
+	}
+
+	gomock.InOrder(
+		store.EXPECT().ExecuteStandingOrderTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(broken, sql.ErrConnDone),
+		// an order that cannot be executed is postponed so it does not block the queue
+		store.EXPECT().
+			PostponeStandingOrder(gomock.Any(), gomock.Any()).
+			Times(1).
+			Do(func(_ context.Context, arg db.PostponeStandingOrderParams) {
+				require.Equal(t, broken.StandingOrder.ID, arg.ID)
+				require.True(t, arg.RetryAt.Valid)
+				require.WithinDuration(t, time.Now().Add(time.Minute), arg.RetryAt.Time, time.Second)
+			}),
+		store.EXPECT().ExecuteStandingOrderTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.ExecuteStandingOrderTxResult{}, sql.ErrNoRows),
+	)
+
+	processor := NewStandingOrderProcessor(store, time.Minute, time.Hour)
+	processor.ProcessDue(context.Background())
+}